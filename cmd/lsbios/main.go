@@ -14,43 +14,48 @@
 package main
 
 import (
-        "fmt"
-        "log"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
 
-        "github.com/axrayn/go-smbios/smbios"
-        "github.com/axrayn/go-smbios/smbios/bios"
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/bios"
 )
 
-
-
-
 func main() {
-        // Find SMBIOS data in operating system-specific location.
-        rc, ep, err := smbios.Stream()
-        if err != nil {
-                log.Fatalf("failed to open stream: %v", err)
-        }
-        // Be sure to close the stream!
-        defer rc.Close()
-
-        // Decode SMBIOS structures from the stream.
-        d := smbios.NewDecoder(rc)
-        ss, err := d.Decode()
-        if err != nil {
-                log.Fatalf("failed to decode structures: %v", err)
-        }
-
-        major, minor, rev := ep.Version()
-        fmt.Printf("SMBIOS %d.%d.%d\n", major, minor, rev)
-
-        for _, s := range ss {
-        	// Only look at memory devices.
-            if s.Header.Type != 0 {
-				continue
-			}
-			var myCache bios.Bios
-            myCache.Get(s)
-            fmt.Printf("%+v\n", myCache)
-        }
+	// Find SMBIOS data in operating system-specific location.
+	rc, ep, err := smbios.Stream()
+	if errors.Is(err, fs.ErrPermission) {
+		// Unprivileged users can still see a summary of the BIOS
+		// information on Linux.
+		rc, ep, err = smbios.StreamWithOptions(&smbios.StreamOptions{
+			Mechanisms: []smbios.Mechanism{smbios.MechanismSysfsDMIID},
+		})
+	}
+	if err != nil {
+		log.Fatalf("failed to open stream: %v", err)
+	}
+	// Be sure to close the stream!
+	defer rc.Close()
+
+	// Decode SMBIOS structures from the stream.
+	tbl, err := smbios.NewTable(smbios.NewDecoder(rc), ep)
+	if err != nil {
+		log.Fatalf("failed to decode structures: %v", err)
+	}
+
+	if _, ok := ep.(*smbios.DMIIDEntryPoint); ok {
+		log.Println("warning: insufficient permissions to read SMBIOS table, showing limited information")
+	} else {
+		major, minor, rev := ep.Version()
+		fmt.Printf("SMBIOS %d.%d.%d\n", major, minor, rev)
+	}
+
+	// Only look at BIOS information.
+	for _, s := range tbl.ByType(0) {
+		var myCache bios.Bios
+		myCache.Get(s)
+		fmt.Printf("%+v\n", myCache)
+	}
 }
-        
\ No newline at end of file
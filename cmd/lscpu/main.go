@@ -25,7 +25,7 @@ import (
 
 func main() {
 	// Find SMBIOS data in operating system-specific location.
-	rc, _, err := smbios.Stream()
+	rc, ep, err := smbios.Stream()
 	if err != nil {
 		log.Fatalf("failed to open stream: %v", err)
 	}
@@ -33,8 +33,7 @@ func main() {
 	defer rc.Close()
 
 	// Decode SMBIOS structures from the stream.
-	d := smbios.NewDecoder(rc)
-	ss, err := d.Decode()
+	tbl, err := smbios.NewTable(smbios.NewDecoder(rc), ep)
 	if err != nil {
 		log.Fatalf("failed to decode structures: %v", err)
 	}
@@ -42,17 +41,22 @@ func main() {
 	//major, minor, rev := ep.Version()
 	//fmt.Printf("SMBIOS %d.%d.%d\n", major, minor, rev)
 
-	for _, s := range ss {
-		// Only look at memory devices.
-		if s.Header.Type != 4 {
-			continue
-		}
-
+	// Only look at processors.
+	for _, s := range tbl.ByType(4) {
 		// Code based on: https://www.dmtf.org/sites/default/files/standards/documents/DSP0134_3.1.1.pdf.
 
-                var myCPU cpu.CPU
+		var myCPU cpu.CPU
 		myCPU.Get(s)
 
 		fmt.Printf("%+v\n", myCPU)
+
+		caches, err := myCPU.Caches(tbl)
+		if err != nil {
+			log.Fatalf("failed to decode caches: %v", err)
+		}
+
+		for _, c := range caches {
+			fmt.Printf("  L%d %s: %d KB\n", c.Level, c.SocketDesignation, c.InstalledSize>>10)
+		}
 	}
 }
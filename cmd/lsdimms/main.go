@@ -16,14 +16,22 @@
 package main
 
 import (
-	"encoding/binary"
+	"flag"
 	"fmt"
 	"log"
+	"strconv"
 
-	"github.com/digitalocean/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/memory"
+)
+
+var (
+	addr = flag.String("addr", "", "show the DIMMs mapped at a physical memory address, such as 0x12345000")
 )
 
 func main() {
+	flag.Parse()
+
 	// Find SMBIOS data in operating system-specific location.
 	rc, ep, err := smbios.Stream()
 	if err != nil {
@@ -33,8 +41,7 @@ func main() {
 	defer rc.Close()
 
 	// Decode SMBIOS structures from the stream.
-	d := smbios.NewDecoder(rc)
-	ss, err := d.Decode()
+	tbl, err := smbios.NewTable(smbios.NewDecoder(rc), ep)
 	if err != nil {
 		log.Fatalf("failed to decode structures: %v", err)
 	}
@@ -42,42 +49,86 @@ func main() {
 	major, minor, rev := ep.Version()
 	fmt.Printf("SMBIOS %d.%d.%d\n", major, minor, rev)
 
-	for _, s := range ss {
-		// Only look at memory devices.
-		if s.Header.Type != 17 {
+	if *addr != "" {
+		showAddress(tbl, *addr)
+		return
+	}
+
+	// Only look at memory devices.
+	for _, s := range tbl.ByType(17) {
+		var d memory.Device
+		if err := d.Get(s); err != nil {
+			log.Fatalf("failed to decode memory device: %v", err)
+		}
+
+		if !d.Installed() {
+			fmt.Printf("[% 3s] empty\n", d.DeviceLocator)
 			continue
 		}
 
-		// Code based on: https://www.dmtf.org/sites/default/files/standards/documents/DSP0134_3.1.1.pdf.
+		fmt.Printf("[% 3s] DIMM: %s\n", d.DeviceLocator, formatSize(d.Size))
+	}
 
-		// TODO: this should go in a new package in go-smbios for parsing specific structures.
+	// Summarize the devices in each memory array.
+	sums, err := memory.Summarize(tbl)
+	if err != nil {
+		log.Fatalf("failed to summarize memory arrays: %v", err)
+	}
 
-		// Only parse the DIMM size.
-		dimmSize := int(binary.LittleEndian.Uint16(s.Formatted[8:10]))
+	for _, s := range sums {
+		fmt.Println(s.String())
+	}
 
-		if dimmSize == 0 {
-			fmt.Printf("[% 3s] empty\n", s.Strings[0])
-			continue
-		}
+	// Report any devices with memory errors.
+	errs, err := memory.DeviceErrors(tbl)
+	if err != nil {
+		log.Fatalf("failed to decode memory errors: %v", err)
+	}
 
-		//If the DIMM size is 32GB or greater, we need to parse the extended field.
-		// Spec says 0x7fff in regular size field means we should parse the extended.
-		if dimmSize == 0x7fff {
-			dimmSize = int(binary.LittleEndian.Uint32(s.Formatted[24:28]))
-		}
+	for _, e := range errs {
+		fmt.Printf("[% 3s] error: %s\n", e.Device.DeviceLocator, e.Error.Type)
+	}
 
-		// The granularity in which the value is specified
-		// depends on the setting of the most-significant bit (bit
-		// 15). If the bit is 0, the value is specified in megabyte
-		// units; if the bit is 1, the value is specified in kilobyte
-		// units.
-		//
-		// Little endian MSB for uint16 is in second byte.
-		unit := "KB"
-		if s.Formatted[9]&0x80 == 0 {
-			unit = "MB"
-		}
+	aerrs, err := memory.ArrayErrors(tbl)
+	if err != nil {
+		log.Fatalf("failed to decode memory array errors: %v", err)
+	}
+
+	for _, e := range aerrs {
+		fmt.Printf("array 0x%04x error: %s\n", e.Handle, e.Error.Type)
+	}
+}
+
+// showAddress displays the memory devices mapped at a physical address.
+func showAddress(tbl *smbios.Table, s string) {
+	a, err := strconv.ParseUint(s, 0, 64)
+	if err != nil {
+		log.Fatalf("invalid address %q: %v", s, err)
+	}
+
+	mds, err := memory.DevicesAt(tbl, a)
+	if err != nil {
+		log.Fatalf("failed to resolve address: %v", err)
+	}
+
+	if len(mds) == 0 {
+		fmt.Printf("0x%x: no mapped DIMMs\n", a)
+		return
+	}
+
+	for _, md := range mds {
+		fmt.Printf("0x%x: [% 3s] %s\n", a, md.Device.DeviceLocator, md.Device.BankLocator)
+	}
+}
 
-		fmt.Printf("[% 3s] DIMM: %d %s\n", s.Strings[0], dimmSize, unit)
+// formatSize formats a memory device size in bytes for display.
+func formatSize(size uint64) string {
+	switch {
+	case size == memory.UnknownSize:
+		return "unknown"
+	case size%(1<<20) != 0:
+		return fmt.Sprintf("%d KB", size>>10)
+	default:
+		return fmt.Sprintf("%d MB", size>>20)
 	}
 }
@@ -16,21 +16,55 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"os"
 
-	"github.com/digitalocean/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios"
+)
+
+var (
+	dumpBin  = flag.String("dump-bin", "", "write SMBIOS data to a file in dmidecode binary dump format instead of displaying it")
+	fromDump = flag.String("from-dump", "", "read SMBIOS data from a file in dmidecode binary dump format")
 )
 
 func main() {
-	// Find SMBIOS data in operating system-specific location.
-	rc, ep, err := smbios.Stream()
+	flag.Parse()
+
+	// Find SMBIOS data in operating system-specific location, or in a
+	// dump file if specified.
+	open := smbios.Stream
+	if *fromDump != "" {
+		open = func() (io.ReadCloser, smbios.EntryPoint, error) {
+			return smbios.OpenDump(*fromDump)
+		}
+	}
+
+	rc, ep, err := open()
 	if err != nil {
 		log.Fatalf("failed to open stream: %v", err)
 	}
 	// Be sure to close the stream!
 	defer rc.Close()
 
+	if *dumpBin != "" {
+		f, err := os.Create(*dumpBin)
+		if err != nil {
+			log.Fatalf("failed to create dump file: %v", err)
+		}
+
+		if err := smbios.WriteDump(f, rc, ep); err != nil {
+			log.Fatalf("failed to write dump: %v", err)
+		}
+		if err := f.Close(); err != nil {
+			log.Fatalf("failed to close dump file: %v", err)
+		}
+
+		return
+	}
+
 	// Decode SMBIOS structures from the stream.
 	d := smbios.NewDecoder(rc)
 	ss, err := d.Decode()
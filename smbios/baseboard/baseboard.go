@@ -0,0 +1,178 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package baseboard decodes SMBIOS Baseboard (or Module) Information (Type 2)
+// structures.
+package baseboard
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/axrayn/go-smbios/smbios"
+)
+
+const (
+	// typeBaseboard is the SMBIOS structure type for Baseboard Information.
+	typeBaseboard = 2
+
+	// typeChassis is the SMBIOS structure type for System Enclosure or
+	// Chassis.
+	typeChassis = 3
+
+	// noChassisHandle indicates that a board has no chassis handle.
+	noChassisHandle = 0xffff
+)
+
+var (
+	// featureFlagList is indexed by bit number.
+	featureFlagList = []string{
+		"Hosting Board",
+		"Requires Daughter Board",
+		"Removable",
+		"Replaceable",
+		"Hot Swappable",
+	}
+
+	boardTypeList = map[int]string{
+		1:  "Unknown",
+		2:  "Other",
+		3:  "Server Blade",
+		4:  "Connectivity Switch",
+		5:  "System Management Module",
+		6:  "Processor Module",
+		7:  "I/O Module",
+		8:  "Memory Module",
+		9:  "Daughter Board",
+		10: "Motherboard",
+		11: "Processor/Memory Module",
+		12: "Processor/IO Module",
+		13: "Interconnect Board",
+	}
+)
+
+// Baseboard Structure for containing Baseboard information
+//
+// ChassisHandle is 0xFFFF if the structure does not include one.
+type Baseboard struct {
+	Manufacturer           string
+	Product                string
+	Version                string
+	SerialNumber           string
+	AssetTag               string
+	FeatureFlags           []string
+	LocationInChassis      string
+	ChassisHandle          uint16
+	BoardType              string
+	ContainedObjectHandles []uint16
+}
+
+// BoardTypeString returns the name of an SMBIOS board type value, which is
+// also used by other structures such as chassis contained elements.
+func BoardTypeString(val uint8) string {
+	return boardTypeList[int(val)]
+}
+
+func getFeatureFlags(val byte) (flags []string) {
+	for bit, name := range featureFlagList {
+		if val&(1<<uint(bit)) != 0 {
+			flags = append(flags, name)
+		}
+	}
+	return flags
+}
+
+// Get Function to build a *Baseboard struct object with all
+// the details from SMBIOS
+func (b *Baseboard) Get(s *smbios.Structure) error {
+	if s.Header.Type != typeBaseboard {
+		return fmt.Errorf("expected SMBIOS structure type %d, but got: %d", typeBaseboard, s.Header.Type)
+	}
+
+	// Only the strings are required; all other fields are optional.
+	const minLen = 4
+	if l := len(s.Formatted); l < minLen {
+		return fmt.Errorf("expected SMBIOS baseboard information length of at least %d, but got: %d", minLen, l)
+	}
+
+	*b = Baseboard{
+		Manufacturer: s.GetString(s.Formatted[0]),
+		Product:      s.GetString(s.Formatted[1]),
+		Version:      s.GetString(s.Formatted[2]),
+		SerialNumber: s.GetString(s.Formatted[3]),
+		// The chassis handle is absent in short structures.
+		ChassisHandle: noChassisHandle,
+	}
+
+	if len(s.Formatted) < 10 {
+		return nil
+	}
+
+	b.AssetTag = s.GetString(s.Formatted[4])
+	b.FeatureFlags = getFeatureFlags(s.Formatted[5])
+	b.LocationInChassis = s.GetString(s.Formatted[6])
+	b.ChassisHandle = binary.LittleEndian.Uint16(s.Formatted[7:9])
+	b.BoardType = BoardTypeString(s.Formatted[9])
+
+	if len(s.Formatted) < 11 {
+		return nil
+	}
+
+	// Contained object handles follow the count of handles.
+	n := int(s.Formatted[10])
+	if l := len(s.Formatted); l < 11+n*2 {
+		return fmt.Errorf("expected %d contained object handles in SMBIOS baseboard information, but structure length is %d", n, l)
+	}
+
+	for i := 0; i < n; i++ {
+		off := 11 + i*2
+		b.ContainedObjectHandles = append(b.ContainedObjectHandles,
+			binary.LittleEndian.Uint16(s.Formatted[off:off+2]))
+	}
+
+	return nil
+}
+
+// Chassis returns the System Enclosure or Chassis structure which contains
+// the board, if it is present in t.  It returns false if the board has no
+// chassis handle or the handle does not refer to a chassis structure.
+func (b *Baseboard) Chassis(t *smbios.Table) (*smbios.Structure, bool) {
+	if b.ChassisHandle == noChassisHandle {
+		return nil, false
+	}
+
+	s, ok := t.ByHandle(b.ChassisHandle)
+	if !ok || s.Header.Type != typeChassis {
+		return nil, false
+	}
+
+	return s, true
+}
+
+// ContainedObjects returns the Structures for each of the board's contained
+// object handles, in order.  An error is returned if any handle does not
+// refer to a Structure in t.
+func (b *Baseboard) ContainedObjects(t *smbios.Table) ([]*smbios.Structure, error) {
+	ss := make([]*smbios.Structure, 0, len(b.ContainedObjectHandles))
+	for _, h := range b.ContainedObjectHandles {
+		s, ok := t.ByHandle(h)
+		if !ok {
+			return nil, fmt.Errorf("baseboard contained object handle 0x%04x does not refer to an SMBIOS structure", h)
+		}
+
+		ss = append(ss, s)
+	}
+
+	return ss, nil
+}
@@ -0,0 +1,237 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baseboard_test
+
+import (
+	"testing"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/baseboard"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBaseboardGet(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		b    *baseboard.Baseboard
+		ok   bool
+	}{
+		{
+			name: "wrong type",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 3}},
+		},
+		{
+			name: "too short",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 2},
+				Formatted: []byte{0x01, 0x02},
+			},
+		},
+		{
+			name: "contained objects truncated",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 2},
+				Formatted: []byte{
+					0x01, 0x02, 0x03, 0x04,
+					0x00,
+					0x01,
+					0x00,
+					0x03, 0x00,
+					0x0a,
+					// Two handles, but only one present.
+					0x02,
+					0x04, 0x00,
+				},
+			},
+		},
+		{
+			name: "OK, 2.0",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 2},
+				Formatted: []byte{0x01, 0x02, 0x03, 0x04},
+				Strings:   []string{"Acme", "Board", "1.0", "S1234"},
+			},
+			b: &baseboard.Baseboard{
+				Manufacturer:  "Acme",
+				Product:       "Board",
+				Version:       "1.0",
+				SerialNumber:  "S1234",
+				ChassisHandle: 0xffff,
+			},
+			ok: true,
+		},
+		{
+			name: "OK, contained objects",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 2},
+				Formatted: []byte{
+					0x01, 0x02, 0x00, 0x03,
+					0x04,
+					0x09,
+					0x05,
+					0x03, 0x00,
+					0x0a,
+					0x02,
+					0x04, 0x00,
+					0x05, 0x00,
+				},
+				Strings: []string{"Acme", "Board", "S1234", "A1234", "Slot 0"},
+			},
+			b: &baseboard.Baseboard{
+				Manufacturer:           "Acme",
+				Product:                "Board",
+				SerialNumber:           "S1234",
+				AssetTag:               "A1234",
+				FeatureFlags:           []string{"Hosting Board", "Replaceable"},
+				LocationInChassis:      "Slot 0",
+				ChassisHandle:          3,
+				BoardType:              "Motherboard",
+				ContainedObjectHandles: []uint16{4, 5},
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b baseboard.Baseboard
+			err := b.Get(tt.s)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.b, &b); diff != "" {
+				t.Fatalf("unexpected baseboard information (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBaseboardChassis(t *testing.T) {
+	bios := &smbios.Structure{Header: smbios.Header{Type: 0, Handle: 0}}
+	chassis := &smbios.Structure{Header: smbios.Header{Type: 3, Handle: 3}}
+	processor := &smbios.Structure{Header: smbios.Header{Type: 4, Handle: 4}}
+	tbl := smbios.NewTableFromStructures([]*smbios.Structure{bios, chassis, processor}, nil)
+
+	tests := []struct {
+		name string
+		h    uint16
+		s    *smbios.Structure
+		ok   bool
+	}{
+		{
+			name: "no handle",
+			h:    0xffff,
+		},
+		{
+			name: "dangling handle",
+			h:    5,
+		},
+		{
+			name: "not a chassis",
+			h:    4,
+		},
+		{
+			name: "BIOS at handle 0",
+			h:    0,
+		},
+		{
+			name: "OK",
+			h:    3,
+			s:    chassis,
+			ok:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &baseboard.Baseboard{ChassisHandle: tt.h}
+			s, ok := b.Chassis(tbl)
+
+			if diff := cmp.Diff(tt.ok, ok); diff != "" {
+				t.Fatalf("unexpected chassis result (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.s, s); diff != "" {
+				t.Fatalf("unexpected chassis (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBaseboardContainedObjects(t *testing.T) {
+	chassis := &smbios.Structure{Header: smbios.Header{Type: 3, Handle: 3}}
+	processor := &smbios.Structure{Header: smbios.Header{Type: 4, Handle: 4}}
+	tbl := smbios.NewTableFromStructures([]*smbios.Structure{chassis, processor}, nil)
+
+	tests := []struct {
+		name string
+		b    *baseboard.Baseboard
+		ss   []*smbios.Structure
+		ok   bool
+	}{
+		{
+			name: "dangling handle",
+			b: &baseboard.Baseboard{
+				ContainedObjectHandles: []uint16{4, 5},
+			},
+		},
+		{
+			name: "OK, none",
+			b:    &baseboard.Baseboard{},
+			ss:   []*smbios.Structure{},
+			ok:   true,
+		},
+		{
+			name: "OK",
+			b: &baseboard.Baseboard{
+				ContainedObjectHandles: []uint16{4, 3},
+			},
+			ss: []*smbios.Structure{processor, chassis},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss, err := tt.b.ContainedObjects(tbl)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.ss, ss); diff != "" {
+				t.Fatalf("unexpected contained objects (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
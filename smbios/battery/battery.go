@@ -0,0 +1,141 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package battery decodes SMBIOS Portable Battery (Type 22) structures.
+package battery
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/axrayn/go-smbios/smbios"
+)
+
+// typeBattery is the SMBIOS structure type for Portable Battery.
+const typeBattery = 22
+
+var (
+	chemistryList = map[int]string{
+		1: "Other",
+		2: "Unknown",
+		3: "Lead Acid",
+		4: "Nickel Cadmium",
+		5: "Nickel metal hydride",
+		6: "Lithium-ion",
+		7: "Zinc air",
+		8: "Lithium Polymer",
+	}
+)
+
+// Battery Structure for containing Portable Battery information
+//
+// When the firmware uses the Smart Battery Data Specification (SBDS) form
+// of a field instead of its string form, ManufactureDate, SerialNumber, and
+// Chemistry are derived from the SBDS fields.  DesignCapacity is in
+// milliwatt-hours with the multiplier applied, DesignVoltage is in
+// millivolts, and both are 0 if they are unknown.  MaximumError is a
+// percentage, or -1 if it is unknown.
+type Battery struct {
+	Location            string
+	Manufacturer        string
+	ManufactureDate     string
+	SBDSManufactureDate time.Time
+	SerialNumber        string
+	SBDSSerialNumber    uint16
+	DeviceName          string
+	Chemistry           string
+	SBDSChemistry       string
+	DesignCapacity      int
+	DesignVoltage       int
+	SBDSVersion         string
+	MaximumError        int
+	OEMSpecific         uint32
+}
+
+// parseSBDSDate parses an SBDS packed date, where bits 15:9 are the year
+// biased by 1980, bits 8:5 are the month, and bits 4:0 are the day.
+func parseSBDSDate(val uint16) time.Time {
+	if val == 0 {
+		return time.Time{}
+	}
+
+	year := 1980 + int(val>>9)
+	month := time.Month((val >> 5) & 0x0f)
+	day := int(val & 0x1f)
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Get Function to build a *Battery struct object with all
+// the details from SMBIOS
+func (b *Battery) Get(s *smbios.Structure) error {
+	if s.Header.Type != typeBattery {
+		return fmt.Errorf("expected SMBIOS structure type %d, but got: %d", typeBattery, s.Header.Type)
+	}
+
+	// Correct minimum length as of SMBIOS 2.1.
+	const minLen = 12
+	f := s.Formatted
+	if l := len(f); l < minLen {
+		return fmt.Errorf("expected SMBIOS portable battery length of at least %d, but got: %d", minLen, l)
+	}
+
+	*b = Battery{
+		Location:        s.GetString(f[0]),
+		Manufacturer:    s.GetString(f[1]),
+		ManufactureDate: s.GetString(f[2]),
+		SerialNumber:    s.GetString(f[3]),
+		DeviceName:      s.GetString(f[4]),
+		Chemistry:       chemistryList[int(f[5])],
+		DesignCapacity:  int(binary.LittleEndian.Uint16(f[6:8])),
+		DesignVoltage:   int(binary.LittleEndian.Uint16(f[8:10])),
+		SBDSVersion:     s.GetString(f[10]),
+		MaximumError:    -1,
+	}
+
+	if f[11] != 0xff {
+		b.MaximumError = int(f[11])
+	}
+
+	// The SBDS fields, capacity multiplier, and OEM-specific value were
+	// added in SMBIOS 2.2.
+	if len(f) < 22 {
+		return nil
+	}
+
+	b.SBDSSerialNumber = binary.LittleEndian.Uint16(f[12:14])
+	b.SBDSManufactureDate = parseSBDSDate(binary.LittleEndian.Uint16(f[14:16]))
+	b.SBDSChemistry = s.GetString(f[16])
+	b.OEMSpecific = binary.LittleEndian.Uint32(f[18:22])
+
+	// A multiplier of 0 is treated as 1 for firmware which leaves it unset.
+	if m := int(f[17]); m > 1 {
+		b.DesignCapacity *= m
+	}
+
+	// The SBDS fields are only used when the corresponding string fields
+	// are not provided.
+	if f[2] == 0 && !b.SBDSManufactureDate.IsZero() {
+		b.ManufactureDate = b.SBDSManufactureDate.Format("2006-01-02")
+	}
+	if f[3] == 0 {
+		b.SerialNumber = fmt.Sprintf("%04X", b.SBDSSerialNumber)
+	}
+	if f[5] == 0x02 && b.SBDSChemistry != "" {
+		b.Chemistry = b.SBDSChemistry
+	}
+
+	return nil
+}
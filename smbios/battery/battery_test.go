@@ -0,0 +1,166 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package battery_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/battery"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBatteryGet(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		b    *battery.Battery
+		ok   bool
+	}{
+		{
+			name: "wrong type",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 21}},
+		},
+		{
+			name: "too short",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 22},
+				Formatted: []byte{0x01, 0x02, 0x03},
+			},
+		},
+		{
+			name: "OK, 2.1",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 22},
+				Formatted: []byte{
+					0x01, 0x02, 0x03, 0x04, 0x05,
+					0x06,
+					0xd0, 0x84,
+					0x2c, 0x2b,
+					0x00,
+					0xff,
+				},
+				Strings: []string{"Rear", "Acme", "01/02/2003", "S1234", "Battery"},
+			},
+			b: &battery.Battery{
+				Location:        "Rear",
+				Manufacturer:    "Acme",
+				ManufactureDate: "01/02/2003",
+				SerialNumber:    "S1234",
+				DeviceName:      "Battery",
+				Chemistry:       "Lithium-ion",
+				DesignCapacity:  34000,
+				DesignVoltage:   11052,
+				MaximumError:    -1,
+			},
+			ok: true,
+		},
+		{
+			name: "OK, 2.2 strings with multiplier",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 22},
+				Formatted: []byte{
+					0x01, 0x02, 0x03, 0x04, 0x05,
+					0x08,
+					0x46, 0x05,
+					0x2c, 0x2b,
+					0x06,
+					0x02,
+					0x34, 0x12,
+					0xae, 0x34,
+					0x00,
+					0x0a,
+					0x78, 0x56, 0x34, 0x12,
+				},
+				Strings: []string{"Rear", "Acme", "01/02/2003", "S1234", "Battery", "3.1"},
+			},
+			b: &battery.Battery{
+				Location:            "Rear",
+				Manufacturer:        "Acme",
+				ManufactureDate:     "01/02/2003",
+				SBDSManufactureDate: time.Date(2006, time.May, 14, 0, 0, 0, 0, time.UTC),
+				SerialNumber:        "S1234",
+				SBDSSerialNumber:    0x1234,
+				DeviceName:          "Battery",
+				Chemistry:           "Lithium Polymer",
+				DesignCapacity:      13500,
+				DesignVoltage:       11052,
+				SBDSVersion:         "3.1",
+				MaximumError:        2,
+				OEMSpecific:         0x12345678,
+			},
+			ok: true,
+		},
+		{
+			name: "OK, 2.2 SBDS",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 22},
+				Formatted: []byte{
+					0x01, 0x02, 0x00, 0x00, 0x03,
+					0x02,
+					0xd0, 0x84,
+					0x00, 0x00,
+					0x04,
+					0x01,
+					0xcd, 0xab,
+					0xae, 0x34,
+					0x05,
+					0x00,
+					0x00, 0x00, 0x00, 0x00,
+				},
+				Strings: []string{"Front", "Acme", "Battery", "3.1", "LION"},
+			},
+			b: &battery.Battery{
+				Location:            "Front",
+				Manufacturer:        "Acme",
+				ManufactureDate:     "2006-05-14",
+				SBDSManufactureDate: time.Date(2006, time.May, 14, 0, 0, 0, 0, time.UTC),
+				SerialNumber:        "ABCD",
+				SBDSSerialNumber:    0xabcd,
+				DeviceName:          "Battery",
+				Chemistry:           "LION",
+				SBDSChemistry:       "LION",
+				DesignCapacity:      34000,
+				SBDSVersion:         "3.1",
+				MaximumError:        1,
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b battery.Battery
+			err := b.Get(tt.s)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.b, &b); diff != "" {
+				t.Fatalf("unexpected portable battery (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
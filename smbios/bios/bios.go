@@ -16,7 +16,6 @@ package bios
 import (
         "encoding/binary"
         "fmt"
-        "strings"
 
         "github.com/axrayn/go-smbios/smbios"
 )
@@ -103,10 +102,11 @@ func getBIOSCharacteristicsFlags(val int) (flags []string) {
 // Get Function to build a *Bios struct object with all
 // the details from SMBIOS
 func (bios *Bios) Get(s *smbios.Structure) error {
-	// SMBIOS returns an index starting from 1, need to -1 for Go slice indices
-	bios.Vendor = strings.TrimSpace(s.Strings[s.Formatted[0] - 1])
-	bios.Version = strings.TrimSpace(s.Strings[s.Formatted[1] - 1])
-	bios.ReleaseDate = strings.TrimSpace(s.Strings[s.Formatted[4] - 1])
+	// String number 0 means no string, so use GetString to avoid indexing
+	// out of range.
+	bios.Vendor = s.GetString(s.Formatted[0])
+	bios.Version = s.GetString(s.Formatted[1])
+	bios.ReleaseDate = s.GetString(s.Formatted[4])
 	bios.StartingAddressSegment = fmt.Sprintf("Starting Address: 0x%04X\n", int(binary.LittleEndian.Uint16(s.Formatted[2:4])))
 	// ROM Size is either here or in the extended bit, depending on the value here being FFh or not
 	if (s.Formatted[5] > 254) {
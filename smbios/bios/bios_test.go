@@ -0,0 +1,93 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bios_test
+
+import (
+	"testing"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/bios"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBiosGet(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		b    *bios.Bios
+	}{
+		{
+			name: "OK",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 0},
+				Formatted: []byte{
+					0x01, 0x02,
+					0x00, 0xf0,
+					0x03,
+					0x0f,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x00,
+					0x05, 0x06,
+					0x01, 0x02,
+				},
+				Strings: []string{"Vendor ", "1.0", "01/02/2020"},
+			},
+			b: &bios.Bios{
+				Vendor:                 "Vendor",
+				Version:                "1.0",
+				ReleaseDate:            "01/02/2020",
+				StartingAddressSegment: "Starting Address: 0xF000\n",
+				ROMSize:                1024,
+				MajorRelease:           5,
+				MinorRelease:           6,
+				FirmwareMajorRelease:   1,
+				FirmwareMinorRelease:   2,
+			},
+		},
+		{
+			name: "OK, no strings",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 0},
+				Formatted: []byte{
+					0x00, 0x00,
+					0x00, 0x00,
+					0x00,
+					0x00,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+					0x00, 0x00,
+					0x00, 0x00,
+					0xff, 0xff,
+				},
+			},
+			b: &bios.Bios{
+				StartingAddressSegment: "Starting Address: 0x0000\n",
+				ROMSize:                64,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bios.Bios
+			if err := b.Get(tt.s); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.b, &b); diff != "" {
+				t.Fatalf("unexpected BIOS (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache decodes SMBIOS Cache Information (Type 7) structures.
+package cache
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/axrayn/go-smbios/smbios"
+)
+
+// typeCache is the SMBIOS structure type for Cache Information.
+const typeCache = 7
+
+var (
+	locationList = map[int]string{
+		0: "Internal",
+		1: "External",
+		2: "Reserved",
+		3: "Unknown",
+	}
+
+	operationalModeList = map[int]string{
+		0: "Write Through",
+		1: "Write Back",
+		2: "Varies With Memory Address",
+		3: "Unknown",
+	}
+
+	// sramTypeList is indexed by bit number.
+	sramTypeList = []string{
+		"Other",
+		"Unknown",
+		"Non-Burst",
+		"Burst",
+		"Pipeline Burst",
+		"Synchronous",
+		"Asynchronous",
+	}
+
+	errorCorrectionList = map[int]string{
+		1: "Other",
+		2: "Unknown",
+		3: "None",
+		4: "Parity",
+		5: "Single-bit ECC",
+		6: "Multi-bit ECC",
+	}
+
+	systemCacheTypeList = map[int]string{
+		1: "Other",
+		2: "Unknown",
+		3: "Instruction",
+		4: "Data",
+		5: "Unified",
+	}
+
+	associativityList = map[int]string{
+		1:  "Other",
+		2:  "Unknown",
+		3:  "Direct Mapped",
+		4:  "2-way Set-Associative",
+		5:  "4-way Set-Associative",
+		6:  "Fully Associative",
+		7:  "8-way Set-Associative",
+		8:  "16-way Set-Associative",
+		9:  "12-way Set-Associative",
+		10: "24-way Set-Associative",
+		11: "32-way Set-Associative",
+		12: "48-way Set-Associative",
+		13: "64-way Set-Associative",
+		14: "20-way Set-Associative",
+	}
+)
+
+// Cache Structure for containing Cache Information
+//
+// Sizes are in bytes, and Speed is in nanoseconds, or 0 if it is unknown.
+type Cache struct {
+	SocketDesignation string
+	Level             int
+	Socketed          bool
+	Location          string
+	Enabled           bool
+	OperationalMode   string
+	MaximumSize       uint64
+	InstalledSize     uint64
+	SupportedSRAMType []string
+	CurrentSRAMType   []string
+	Speed             int
+	ErrorCorrection   string
+	SystemCacheType   string
+	Associativity     string
+}
+
+func getFlags(val uint16, list []string) (flags []string) {
+	for bit, name := range list {
+		if val&(1<<uint(bit)) != 0 {
+			flags = append(flags, name)
+		}
+	}
+	return flags
+}
+
+// getSize returns a cache size in bytes from a 2.x size field.
+func getSize(val uint16) uint64 {
+	// The most significant bit selects 64K granularity, otherwise 1K.
+	if val&0x8000 != 0 {
+		return uint64(val&0x7fff) * 64 << 10
+	}
+	return uint64(val) << 10
+}
+
+// getSize2 returns a cache size in bytes from a 3.1 size 2 field.
+func getSize2(val uint32) uint64 {
+	// The most significant bit selects 64K granularity, otherwise 1K.
+	if val&0x80000000 != 0 {
+		return uint64(val&0x7fffffff) * 64 << 10
+	}
+	return uint64(val) << 10
+}
+
+// Get Function to build a *Cache struct object with all
+// the details from SMBIOS
+func (c *Cache) Get(s *smbios.Structure) error {
+	if s.Header.Type != typeCache {
+		return fmt.Errorf("expected SMBIOS structure type %d, but got: %d", typeCache, s.Header.Type)
+	}
+
+	// Correct minimum length as of SMBIOS 2.0.
+	const minLen = 11
+	f := s.Formatted
+	if l := len(f); l < minLen {
+		return fmt.Errorf("expected SMBIOS cache information length of at least %d, but got: %d", minLen, l)
+	}
+
+	config := binary.LittleEndian.Uint16(f[1:3])
+	*c = Cache{
+		SocketDesignation: s.GetString(f[0]),
+		Level:             int(config&0x07) + 1,
+		Socketed:          config&0x08 != 0,
+		Location:          locationList[int(config>>5)&0x03],
+		Enabled:           config&0x80 != 0,
+		OperationalMode:   operationalModeList[int(config>>8)&0x03],
+		SupportedSRAMType: getFlags(binary.LittleEndian.Uint16(f[7:9]), sramTypeList),
+		CurrentSRAMType:   getFlags(binary.LittleEndian.Uint16(f[9:11]), sramTypeList),
+	}
+
+	// Sizes which do not fit in the 2.x fields are set to 0xFFFF, and are
+	// instead stored in the size 2 fields added in SMBIOS 3.1.
+	maxSize := binary.LittleEndian.Uint16(f[3:5])
+	installed := binary.LittleEndian.Uint16(f[5:7])
+	c.MaximumSize = getSize(maxSize)
+	c.InstalledSize = getSize(installed)
+
+	if len(f) >= 23 {
+		if maxSize == 0xffff {
+			c.MaximumSize = getSize2(binary.LittleEndian.Uint32(f[15:19]))
+		}
+		if installed == 0xffff {
+			c.InstalledSize = getSize2(binary.LittleEndian.Uint32(f[19:23]))
+		}
+	}
+
+	// Speed, error correction, system cache type, and associativity were
+	// added in SMBIOS 2.1.
+	if len(f) < 15 {
+		return nil
+	}
+	c.Speed = int(f[11])
+	c.ErrorCorrection = errorCorrectionList[int(f[12])]
+	c.SystemCacheType = systemCacheTypeList[int(f[13])]
+	c.Associativity = associativityList[int(f[14])]
+
+	return nil
+}
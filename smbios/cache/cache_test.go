@@ -0,0 +1,167 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/cache"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCacheGet(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		c    *cache.Cache
+		ok   bool
+	}{
+		{
+			name: "wrong type",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 4}},
+		},
+		{
+			name: "too short",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 7},
+				Formatted: []byte{0x01, 0x80, 0x01},
+			},
+		},
+		{
+			name: "OK, 2.0",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 7},
+				Formatted: []byte{
+					0x01,
+					0x88, 0x01,
+					0x00, 0x02,
+					0x00, 0x01,
+					0x20, 0x00,
+					0x20, 0x00,
+				},
+				Strings: []string{"L1 Cache"},
+			},
+			c: &cache.Cache{
+				SocketDesignation: "L1 Cache",
+				Level:             1,
+				Socketed:          true,
+				Location:          "Internal",
+				Enabled:           true,
+				OperationalMode:   "Write Back",
+				MaximumSize:       512 << 10,
+				InstalledSize:     256 << 10,
+				SupportedSRAMType: []string{"Synchronous"},
+				CurrentSRAMType:   []string{"Synchronous"},
+			},
+			ok: true,
+		},
+		{
+			name: "OK, 2.1, 64K granularity",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 7},
+				Formatted: []byte{
+					0x01,
+					0xa2, 0x02,
+					0x00, 0x81,
+					0x80, 0x80,
+					0x02, 0x00,
+					0x02, 0x00,
+					0x0a,
+					0x05,
+					0x05,
+					0x08,
+				},
+				Strings: []string{"L3 Cache"},
+			},
+			c: &cache.Cache{
+				SocketDesignation: "L3 Cache",
+				Level:             3,
+				Location:          "External",
+				Enabled:           true,
+				OperationalMode:   "Varies With Memory Address",
+				MaximumSize:       16 << 20,
+				InstalledSize:     8 << 20,
+				SupportedSRAMType: []string{"Unknown"},
+				CurrentSRAMType:   []string{"Unknown"},
+				Speed:             10,
+				ErrorCorrection:   "Single-bit ECC",
+				SystemCacheType:   "Unified",
+				Associativity:     "16-way Set-Associative",
+			},
+			ok: true,
+		},
+		{
+			name: "OK, 3.1, size 2",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 7},
+				Formatted: []byte{
+					0x01,
+					0x82, 0x01,
+					0xff, 0xff,
+					0x00, 0x81,
+					0x20, 0x00,
+					0x20, 0x00,
+					0x00,
+					0x06,
+					0x05,
+					0x0b,
+					// 4096 MiB in 64K units, and 16 MiB in 64K units.
+					0x00, 0x00, 0x01, 0x80,
+					0x00, 0x01, 0x00, 0x80,
+				},
+				Strings: []string{"L3 Cache"},
+			},
+			c: &cache.Cache{
+				SocketDesignation: "L3 Cache",
+				Level:             3,
+				Location:          "Internal",
+				Enabled:           true,
+				OperationalMode:   "Write Back",
+				MaximumSize:       4 << 30,
+				InstalledSize:     16 << 20,
+				SupportedSRAMType: []string{"Synchronous"},
+				CurrentSRAMType:   []string{"Synchronous"},
+				ErrorCorrection:   "Multi-bit ECC",
+				SystemCacheType:   "Unified",
+				Associativity:     "32-way Set-Associative",
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c cache.Cache
+			err := c.Get(tt.s)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.c, &c); diff != "" {
+				t.Fatalf("unexpected cache information (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chassis decodes SMBIOS System Enclosure or Chassis (Type 3)
+// structures.
+package chassis
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/baseboard"
+)
+
+// typeChassis is the SMBIOS structure type for System Enclosure or Chassis.
+const typeChassis = 3
+
+var (
+	chassisTypeList = map[int]string{
+		1:  "Other",
+		2:  "Unknown",
+		3:  "Desktop",
+		4:  "Low Profile Desktop",
+		5:  "Pizza Box",
+		6:  "Mini Tower",
+		7:  "Tower",
+		8:  "Portable",
+		9:  "Laptop",
+		10: "Notebook",
+		11: "Hand Held",
+		12: "Docking Station",
+		13: "All in One",
+		14: "Sub Notebook",
+		15: "Space-saving",
+		16: "Lunch Box",
+		17: "Main Server Chassis",
+		18: "Expansion Chassis",
+		19: "SubChassis",
+		20: "Bus Expansion Chassis",
+		21: "Peripheral Chassis",
+		22: "RAID Chassis",
+		23: "Rack Mount Chassis",
+		24: "Sealed-case PC",
+		25: "Multi-system Chassis",
+		26: "Compact PCI",
+		27: "Advanced TCA",
+		28: "Blade",
+		29: "Blade Enclosure",
+		30: "Tablet",
+		31: "Convertible",
+		32: "Detachable",
+		33: "IoT Gateway",
+		34: "Embedded PC",
+		35: "Mini PC",
+		36: "Stick PC",
+	}
+
+	stateList = map[int]string{
+		1: "Other",
+		2: "Unknown",
+		3: "Safe",
+		4: "Warning",
+		5: "Critical",
+		6: "Non-recoverable",
+	}
+
+	securityStatusList = map[int]string{
+		1: "Other",
+		2: "Unknown",
+		3: "None",
+		4: "External Interface Locked Out",
+		5: "External Interface Enabled",
+	}
+)
+
+// A ContainedElement describes a type of element which may be installed in
+// the chassis, and how many of them.
+type ContainedElement struct {
+	// StructureType reports whether Type is an SMBIOS structure type.
+	// Otherwise, Type is an SMBIOS board type.
+	StructureType bool
+	Type          uint8
+	Description   string
+	Minimum       int
+	Maximum       int
+}
+
+// Chassis Structure for containing System Enclosure or Chassis information
+type Chassis struct {
+	Manufacturer       string
+	Type               string
+	Lock               bool
+	Version            string
+	SerialNumber       string
+	AssetTag           string
+	BootUpState        string
+	PowerSupplyState   string
+	ThermalState       string
+	SecurityStatus     string
+	OEMDefined         uint32
+	Height             int
+	NumberOfPowerCords int
+	ContainedElements  []ContainedElement
+	SKUNumber          string
+}
+
+// Get Function to build a *Chassis struct object with all
+// the details from SMBIOS
+func (c *Chassis) Get(s *smbios.Structure) error {
+	if s.Header.Type != typeChassis {
+		return fmt.Errorf("expected SMBIOS structure type %d, but got: %d", typeChassis, s.Header.Type)
+	}
+
+	// The SMBIOS 2.0 structure ends after the asset tag.
+	const minLen = 5
+	if l := len(s.Formatted); l < minLen {
+		return fmt.Errorf("expected SMBIOS chassis information length of at least %d, but got: %d", minLen, l)
+	}
+
+	// The most significant bit of the type indicates a chassis lock.
+	*c = Chassis{
+		Manufacturer: s.GetString(s.Formatted[0]),
+		Type:         chassisTypeList[int(s.Formatted[1]&0x7f)],
+		Lock:         s.Formatted[1]&0x80 != 0,
+		Version:      s.GetString(s.Formatted[2]),
+		SerialNumber: s.GetString(s.Formatted[3]),
+		AssetTag:     s.GetString(s.Formatted[4]),
+	}
+
+	// States were added in SMBIOS 2.1.
+	if len(s.Formatted) < 9 {
+		return nil
+	}
+
+	c.BootUpState = stateList[int(s.Formatted[5])]
+	c.PowerSupplyState = stateList[int(s.Formatted[6])]
+	c.ThermalState = stateList[int(s.Formatted[7])]
+	c.SecurityStatus = securityStatusList[int(s.Formatted[8])]
+
+	// Remaining fields were added in SMBIOS 2.3.
+	if len(s.Formatted) < 17 {
+		return nil
+	}
+
+	c.OEMDefined = binary.LittleEndian.Uint32(s.Formatted[9:13])
+	c.Height = int(s.Formatted[13])
+	c.NumberOfPowerCords = int(s.Formatted[14])
+
+	// Contained element records follow the count and length of each record.
+	n, m := int(s.Formatted[15]), int(s.Formatted[16])
+	end := 17 + n*m
+	if l := len(s.Formatted); l < end {
+		return fmt.Errorf("expected %d contained elements of length %d in SMBIOS chassis information, but structure length is %d", n, m, l)
+	}
+
+	// Each record must contain at least a type, minimum, and maximum.
+	if n > 0 && m < 3 {
+		return fmt.Errorf("SMBIOS chassis contained element record length too short: %d", m)
+	}
+
+	for i := 0; i < n; i++ {
+		c.ContainedElements = append(c.ContainedElements, parseElement(s.Formatted[17+i*m:]))
+	}
+
+	// SKU number was added in SMBIOS 2.7, and follows the contained elements.
+	if len(s.Formatted) > end {
+		c.SKUNumber = s.GetString(s.Formatted[end])
+	}
+
+	return nil
+}
+
+// parseElement parses a ContainedElement from the beginning of b.
+func parseElement(b []byte) ContainedElement {
+	e := ContainedElement{
+		// The most significant bit selects the kind of type.
+		StructureType: b[0]&0x80 != 0,
+		Type:          b[0] & 0x7f,
+		Minimum:       int(b[1]),
+		Maximum:       int(b[2]),
+	}
+
+	if e.StructureType {
+		e.Description = fmt.Sprintf("SMBIOS Type %d", e.Type)
+	} else {
+		e.Description = baseboard.BoardTypeString(e.Type)
+	}
+
+	return e
+}
@@ -0,0 +1,138 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chassis_test
+
+import (
+	"testing"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/chassis"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestChassisGet(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		c    *chassis.Chassis
+		ok   bool
+	}{
+		{
+			name: "wrong type",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 2}},
+		},
+		{
+			name: "elements truncated",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 3},
+				Formatted: []byte{
+					0x00, 0x17, 0x00, 0x00, 0x00,
+					0x03, 0x03, 0x03, 0x03,
+					0x00, 0x00, 0x00, 0x00,
+					0x02,
+					0x02,
+					0x02, 0x03,
+					0x8a, 0x01, 0x02,
+				},
+			},
+		},
+		{
+			name: "OK, 2.0",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 3},
+				Formatted: []byte{0x01, 0x83, 0x00, 0x02, 0x00},
+				Strings:   []string{"Acme", "S1234"},
+			},
+			c: &chassis.Chassis{
+				Manufacturer: "Acme",
+				Type:         "Desktop",
+				Lock:         true,
+				SerialNumber: "S1234",
+			},
+			ok: true,
+		},
+		{
+			name: "OK, 2.7",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 3},
+				Formatted: []byte{
+					0x01, 0x17, 0x00, 0x02, 0x00,
+					0x03, 0x04, 0x03, 0x03,
+					0x78, 0x56, 0x34, 0x12,
+					0x02,
+					0x02,
+					// Two 3-byte contained element records.
+					0x02, 0x03,
+					0x84, 0x01, 0x02,
+					0x0a, 0x01, 0x01,
+					0x03,
+				},
+				Strings: []string{"Acme", "S1234", "SKU-1"},
+			},
+			c: &chassis.Chassis{
+				Manufacturer:       "Acme",
+				Type:               "Rack Mount Chassis",
+				SerialNumber:       "S1234",
+				BootUpState:        "Safe",
+				PowerSupplyState:   "Warning",
+				ThermalState:       "Safe",
+				SecurityStatus:     "None",
+				OEMDefined:         0x12345678,
+				Height:             2,
+				NumberOfPowerCords: 2,
+				ContainedElements: []chassis.ContainedElement{
+					{
+						StructureType: true,
+						Type:          4,
+						Description:   "SMBIOS Type 4",
+						Minimum:       1,
+						Maximum:       2,
+					},
+					{
+						Type:        10,
+						Description: "Motherboard",
+						Minimum:     1,
+						Maximum:     1,
+					},
+				},
+				SKUNumber: "SKU-1",
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c chassis.Chassis
+			err := c.Get(tt.s)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.c, &c); diff != "" {
+				t.Fatalf("unexpected chassis information (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,10 +18,18 @@ package cpu
 
 import (
 	"encoding/binary"
-	"fmt"
 	"strings"
 
 	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/cache"
+)
+
+const (
+	// typeCache is the SMBIOS structure type for Cache Information.
+	typeCache = 7
+
+	// noCacheHandle indicates that a cache handle is not provided.
+	noCacheHandle = 0xffff
 )
 
 var (
@@ -429,9 +437,9 @@ func (cpu *CPU) Get(s *smbios.Structure) error {
 	cpu.ExternalClock = int(binary.LittleEndian.Uint16(s.Formatted[14:16]))
 	cpu.MaxSpeed = int(binary.LittleEndian.Uint16(s.Formatted[16:18]))
 	cpu.CurrentSpeed = int(binary.LittleEndian.Uint16(s.Formatted[18:20]))
-	cpu.L1CacheHandle = fmt.Sprintf("0x%04d", binary.LittleEndian.Uint16(s.Formatted[22:24]))
-	cpu.L2CacheHandle = fmt.Sprintf("0x%04d", binary.LittleEndian.Uint16(s.Formatted[24:26]))
-	cpu.L3CacheHandle = fmt.Sprintf("0x%04d", binary.LittleEndian.Uint16(s.Formatted[26:28]))
+	cpu.L1CacheHandle = binary.LittleEndian.Uint16(s.Formatted[22:24])
+	cpu.L2CacheHandle = binary.LittleEndian.Uint16(s.Formatted[24:26])
+	cpu.L3CacheHandle = binary.LittleEndian.Uint16(s.Formatted[26:28])
 	cpu.StatusFlags = getCPUStatusFlags(int(s.Formatted[20]))
 	cpu.ProcessorUpgrade = processorUpgradeList[int(s.Formatted[21])]
 	cpu.CoreCount = int(s.Formatted[31])
@@ -445,6 +453,33 @@ func (cpu *CPU) Get(s *smbios.Structure) error {
 	return nil
 }
 
+// Caches resolves the CPU's L1, L2, and L3 cache handles to their Cache
+// Information structures in t, in that order.  Handles which are not
+// provided, or which do not refer to a Cache Information structure in t,
+// are skipped.
+func (cpu *CPU) Caches(t *smbios.Table) ([]cache.Cache, error) {
+	var caches []cache.Cache
+	for _, h := range []uint16{cpu.L1CacheHandle, cpu.L2CacheHandle, cpu.L3CacheHandle} {
+		if h == noCacheHandle {
+			continue
+		}
+
+		s, ok := t.ByHandle(h)
+		if !ok || s.Header.Type != typeCache {
+			continue
+		}
+
+		var c cache.Cache
+		if err := c.Get(s); err != nil {
+			return nil, err
+		}
+
+		caches = append(caches, c)
+	}
+
+	return caches, nil
+}
+
 // CPU Structure for containing Processor information
 type CPU struct {
 	SocketDesignation        string
@@ -462,9 +497,9 @@ type CPU struct {
 	CurrentSpeed             int
 	StatusFlags              []string
 	ProcessorUpgrade         string
-	L1CacheHandle            string
-	L2CacheHandle            string
-	L3CacheHandle            string
+	L1CacheHandle            uint16
+	L2CacheHandle            uint16
+	L3CacheHandle            uint16
 	SerialNumber             string
 	AssetTag                 string
 	PartNumber               string
@@ -0,0 +1,150 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//+build !dragonfly,!freebsd,linux,!netbsd,!openbsd,!solaris,!windows
+
+package cpu_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/cache"
+	"github.com/axrayn/go-smbios/smbios/cpu"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCPUCaches(t *testing.T) {
+	// cacheInfo returns an SMBIOS 2.0 cache structure and its decoded form.
+	cacheInfo := func(h uint16, level int) (*smbios.Structure, cache.Cache) {
+		name := fmt.Sprintf("L%d Cache", level)
+
+		s := &smbios.Structure{
+			Header: smbios.Header{Type: 7, Handle: h},
+			Formatted: []byte{
+				0x01,
+				0x80 | uint8(level-1), 0x01,
+				0x00, 0x02,
+				0x00, 0x01,
+				0x20, 0x00,
+				0x20, 0x00,
+			},
+			Strings: []string{name},
+		}
+
+		c := cache.Cache{
+			SocketDesignation: name,
+			Level:             level,
+			Location:          "Internal",
+			Enabled:           true,
+			OperationalMode:   "Write Back",
+			MaximumSize:       512 << 10,
+			InstalledSize:     256 << 10,
+			SupportedSRAMType: []string{"Synchronous"},
+			CurrentSRAMType:   []string{"Synchronous"},
+		}
+
+		return s, c
+	}
+
+	l1s, l1 := cacheInfo(0x0010, 1)
+	l2s, l2 := cacheInfo(0x0011, 2)
+	l3s, l3 := cacheInfo(0x0012, 3)
+
+	processor := &smbios.Structure{Header: smbios.Header{Type: 4, Handle: 0x0004}}
+	short := &smbios.Structure{
+		Header:    smbios.Header{Type: 7, Handle: 0x0013},
+		Formatted: []byte{0x01, 0x80, 0x01},
+	}
+
+	tbl := smbios.NewTableFromStructures([]*smbios.Structure{processor, l1s, l2s, l3s, short}, nil)
+
+	tests := []struct {
+		name string
+		c    *cpu.CPU
+		cs   []cache.Cache
+		ok   bool
+	}{
+		{
+			name: "bad cache structure",
+			c: &cpu.CPU{
+				L1CacheHandle: 0x0010,
+				L2CacheHandle: 0x0013,
+				L3CacheHandle: 0xffff,
+			},
+		},
+		{
+			name: "OK, none",
+			c: &cpu.CPU{
+				L1CacheHandle: 0xffff,
+				L2CacheHandle: 0xffff,
+				L3CacheHandle: 0xffff,
+			},
+			ok: true,
+		},
+		{
+			name: "OK, L1, L2, and L3",
+			c: &cpu.CPU{
+				L1CacheHandle: 0x0010,
+				L2CacheHandle: 0x0011,
+				L3CacheHandle: 0x0012,
+			},
+			cs: []cache.Cache{l1, l2, l3},
+			ok: true,
+		},
+		{
+			name: "OK, L3 not provided",
+			c: &cpu.CPU{
+				L1CacheHandle: 0x0010,
+				L2CacheHandle: 0x0011,
+				L3CacheHandle: 0xffff,
+			},
+			cs: []cache.Cache{l1, l2},
+			ok: true,
+		},
+		{
+			name: "OK, dangling and non-cache handles skipped",
+			c: &cpu.CPU{
+				L1CacheHandle: 0x0020,
+				L2CacheHandle: 0x0004,
+				L3CacheHandle: 0x0012,
+			},
+			cs: []cache.Cache{l3},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs, err := tt.c.Caches(tbl)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.cs, cs); diff != "" {
+				t.Fatalf("unexpected caches (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
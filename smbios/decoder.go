@@ -36,9 +36,22 @@ var (
 )
 
 // A Decoder decodes Structures from a stream.
+//
+// A Decoder can be used as an iterator by calling Next until it returns
+// false, or all at once by calling Decode.
 type Decoder struct {
 	br *bufio.Reader
 	b  []byte
+
+	// State used when iterating with Next.
+	s    *Structure
+	err  error
+	done bool
+
+	// The byte offset in the stream and the number of Structures decoded,
+	// used to report errors.
+	off int
+	n   int
 }
 
 // Stream locates and opens a stream of SMBIOS data and the SMBIOS entry
@@ -46,8 +59,10 @@ type Decoder struct {
 // closed after decoding to free its resources.
 //
 // If no suitable location is found, an error is returned.
+//
+// Use StreamWithOptions to configure where SMBIOS data is located.
 func Stream() (io.ReadCloser, EntryPoint, error) {
-	rc, ep, err := stream()
+	rc, ep, err := streamWithOptions(nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -71,30 +86,70 @@ func NewDecoder(r io.Reader) *Decoder {
 
 // Decode decodes Structures from the Decoder's stream until an End-of-table
 // structure is found.
+//
+// If any Structure cannot be decoded, no Structures are returned.  Use Next
+// to retain the Structures decoded before an error occurs.
 func (d *Decoder) Decode() ([]*Structure, error) {
 	var ss []*Structure
+	for d.Next() {
+		ss = append(ss, d.Structure())
+	}
 
-	for {
-		s, err := d.next()
-		if err != nil {
-			return nil, err
-		}
-
-		// End-of-table structure indicates end of stream.
-		ss = append(ss, s)
-		if s.Header.Type == typeEndOfTable {
-			break
-		}
+	if err := d.Err(); err != nil {
+		return nil, err
 	}
 
 	return ss, nil
 }
 
+// Next advances the Decoder to the next Structure in the stream, which is
+// then available using the Structure method.  Next returns false once the
+// End-of-table structure has been returned or an error occurs.  After Next
+// returns false, the Err method returns any error that occurred.
+//
+// Errors are reported as a *DecodeError.  A stream which ends before an
+// End-of-table structure is found results in a *DecodeError which wraps
+// io.ErrUnexpectedEOF.
+func (d *Decoder) Next() bool {
+	if d.done {
+		return false
+	}
+
+	s, err := d.next()
+	if err != nil {
+		d.s = nil
+		d.err = err
+		d.done = true
+		return false
+	}
+
+	// End-of-table structure indicates end of stream, but is still
+	// returned to the caller.
+	d.s = s
+	if s.Header.Type == typeEndOfTable {
+		d.done = true
+	}
+
+	return true
+}
+
+// Structure returns the most recent Structure decoded by a call to Next.
+func (d *Decoder) Structure() *Structure {
+	return d.s
+}
+
+// Err returns the first error encountered by Next, if any.
+func (d *Decoder) Err() error {
+	return d.err
+}
+
 // next decodes the next Structure from the stream.
 func (d *Decoder) next() (*Structure, error) {
+	start := d.off
+
 	h, err := d.parseHeader()
 	if err != nil {
-		return nil, err
+		return nil, d.decodeError(start, nil, err)
 	}
 
 	// Length of formatted section is length specified by header, minus
@@ -102,14 +157,16 @@ func (d *Decoder) next() (*Structure, error) {
 	l := int(h.Length) - headerLen
 	fb, err := d.parseFormatted(l)
 	if err != nil {
-		return nil, err
+		return nil, d.decodeError(start, h, err)
 	}
 
 	ss, err := d.parseStrings()
 	if err != nil {
-		return nil, err
+		return nil, d.decodeError(start, h, err)
 	}
 
+	d.n++
+
 	return &Structure{
 		Header:    *h,
 		Formatted: fb,
@@ -117,11 +174,29 @@ func (d *Decoder) next() (*Structure, error) {
 	}, nil
 }
 
+// decodeError creates a *DecodeError for the Structure beginning at offset
+// start, with Header h if it was decoded.
+func (d *Decoder) decodeError(start int, h *Header, err error) error {
+	// Any end of stream while decoding a Structure means the stream was
+	// truncated, since it must end with an End-of-table structure.
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return &DecodeError{
+		Offset: start,
+		Index:  d.n,
+		Header: h,
+		Err:    err,
+	}
+}
+
 // parseHeader parses a Structure's Header from the stream.
 func (d *Decoder) parseHeader() (*Header, error) {
 	if _, err := io.ReadFull(d.br, d.b[:headerLen]); err != nil {
 		return nil, err
 	}
+	d.off += headerLen
 
 	return &Header{
 		Type:   d.b[0],
@@ -144,6 +219,7 @@ func (d *Decoder) parseFormatted(l int) ([]byte, error) {
 	if _, err := io.ReadFull(d.br, d.b[:l]); err != nil {
 		return nil, err
 	}
+	d.off += l
 
 	// Make a copy to free up the internal buffer.
 	fb := make([]byte, len(d.b[:l]))
@@ -165,6 +241,7 @@ func (d *Decoder) parseStrings() ([]string, error) {
 		if _, err := d.br.Discard(2); err != nil {
 			return nil, err
 		}
+		d.off += 2
 
 		return nil, nil
 	}
@@ -197,6 +274,7 @@ func (d *Decoder) parseString() (str string, more bool, err error) {
 	if err != nil {
 		return "", false, err
 	}
+	d.off += len(raw)
 
 	b := bytes.TrimRight(raw, "\x00")
 
@@ -215,6 +293,7 @@ func (d *Decoder) parseString() (str string, more bool, err error) {
 	if _, err := d.br.Discard(1); err != nil {
 		return "", false, err
 	}
+	d.off++
 
 	return string(b), false, nil
 }
@@ -16,6 +16,8 @@ package smbios_test
 
 import (
 	"bytes"
+	"errors"
+	"io"
 	"testing"
 
 	"github.com/digitalocean/go-smbios/smbios"
@@ -190,3 +192,180 @@ func TestDecoder(t *testing.T) {
 		})
 	}
 }
+
+func TestDecoderNext(t *testing.T) {
+	tests := []struct {
+		name string
+		b    []byte
+		ss   []*smbios.Structure
+		ok   bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "no end of table",
+			b: []byte{
+				0x01, 0x04, 0x01, 0x00,
+				0x00,
+				0x00,
+			},
+			ss: []*smbios.Structure{{
+				Header: smbios.Header{
+					Type:   1,
+					Length: 4,
+					Handle: 1,
+				},
+			}},
+		},
+		{
+			name: "bad second message",
+			b: []byte{
+				0x01, 0x0c, 0x02, 0x00,
+				0xde, 0xad, 0xbe, 0xef, 0xde, 0xad, 0xbe, 0xef,
+				'd', 'e', 'a', 'd', 'b', 'e', 'e', 'f', 0x00,
+				0x00,
+
+				0xff,
+			},
+			ss: []*smbios.Structure{{
+				Header: smbios.Header{
+					Type:   1,
+					Length: 12,
+					Handle: 2,
+				},
+				Formatted: []byte{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad, 0xbe, 0xef},
+				Strings:   []string{"deadbeef"},
+			}},
+		},
+		{
+			name: "OK, data after end of table",
+			b: []byte{
+				0x00, 0x05, 0x01, 0x00,
+				0xff,
+				0x00,
+				0x00,
+
+				127, 0x04, 0x02, 0x00,
+				0x00,
+				0x00,
+
+				0xff, 0xff, 0xff,
+			},
+			ss: []*smbios.Structure{
+				{
+					Header: smbios.Header{
+						Type:   0,
+						Length: 5,
+						Handle: 1,
+					},
+					Formatted: []byte{0xff},
+				},
+				{
+					Header: smbios.Header{
+						Type:   127,
+						Length: 4,
+						Handle: 2,
+					},
+				},
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := smbios.NewDecoder(bytes.NewReader(tt.b))
+
+			var ss []*smbios.Structure
+			for d.Next() {
+				ss = append(ss, d.Structure())
+			}
+			err := d.Err()
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.ss, ss); diff != "" {
+				t.Fatalf("unexpected structures (-want +got):\n%s", diff)
+			}
+
+			if d.Next() {
+				t.Fatal("expected no more structures after Next returned false")
+			}
+		})
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		b    []byte
+		err  *smbios.DecodeError
+	}{
+		{
+			name: "short header",
+			b:    []byte{0x00},
+			err:  &smbios.DecodeError{},
+		},
+		{
+			name: "no end of table",
+			b: []byte{
+				0x01, 0x04, 0x01, 0x00,
+				0x00,
+				0x00,
+			},
+			err: &smbios.DecodeError{
+				Offset: 6,
+				Index:  1,
+			},
+		},
+		{
+			name: "truncated strings",
+			b: []byte{
+				0x01, 0x05, 0x01, 0x00,
+				0xff,
+				'a', 'b', 0x00,
+				0x00,
+
+				0x11, 0x05, 0x02, 0x00,
+				0xff,
+				'a', 'b', 'c',
+			},
+			err: &smbios.DecodeError{
+				Offset: 9,
+				Index:  1,
+				Header: &smbios.Header{
+					Type:   17,
+					Length: 5,
+					Handle: 2,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := smbios.NewDecoder(bytes.NewReader(tt.b)).Decode()
+			if !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Fatalf("expected unexpected EOF, but got: %v", err)
+			}
+
+			var derr *smbios.DecodeError
+			if !errors.As(err, &derr) {
+				t.Fatalf("expected decode error, but got: %T", err)
+			}
+
+			// The underlying error was already checked.
+			derr.Err = nil
+
+			if diff := cmp.Diff(tt.err, derr); diff != "" {
+				t.Fatalf("unexpected decode error (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
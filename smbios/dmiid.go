@@ -0,0 +1,259 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package smbios
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Structure types synthesized from /sys/class/dmi/id.
+const (
+	typeBIOS      = 0
+	typeSystem    = 1
+	typeBaseboard = 2
+	typeChassis   = 3
+)
+
+// Values which indicate that a field's contents are unknown.
+const (
+	// unknownEnum is the "Unknown" value for most enumerated fields.
+	unknownEnum = 0x02
+
+	// unknownRelease is used for release fields which are not supported.
+	unknownRelease = 0xff
+
+	// biosCharacteristicsNotSupported is bit 3 of the BIOS characteristics.
+	biosCharacteristicsNotSupported = 1 << 3
+)
+
+var _ EntryPoint = &DMIIDEntryPoint{}
+
+// A DMIIDEntryPoint is returned along with the structures synthesized by
+// MechanismSysfsDMIID.  Its presence indicates a degraded source of SMBIOS
+// data: only the BIOS, System, Baseboard, and Chassis structures are present,
+// many fields are zero or "Unknown", and fields which Linux only allows
+// privileged processes to read (such as serial numbers and the system UUID)
+// are usually missing.
+//
+// The synthesized structures use the SMBIOS 2.8 layouts.
+type DMIIDEntryPoint struct {
+	// Path is the directory from which the structures were synthesized.
+	Path string
+
+	// Size is the size of the synthesized structure table.
+	Size int
+}
+
+// Table implements EntryPoint.  The returned address will always be 0, as
+// the structures do not exist in memory.
+func (e *DMIIDEntryPoint) Table() (address, size int) {
+	return 0, e.Size
+}
+
+// Version implements EntryPoint.
+func (e *DMIIDEntryPoint) Version() (major, minor, revision int) {
+	return 2, 8, 0
+}
+
+// dmiIDStream synthesizes an SMBIOS structure stream from the files in dir,
+// which is usually /sys/class/dmi/id.
+func dmiIDStream(dir string) (io.ReadCloser, EntryPoint, error) {
+	// Ensure the directory exists so the caller can fall back to another
+	// mechanism if it does not.
+	if _, err := os.Stat(dir); err != nil {
+		return nil, nil, err
+	}
+
+	// Missing or unreadable files are treated as empty.
+	read := func(name string) string {
+		b, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return ""
+		}
+
+		return strings.TrimSpace(string(b))
+	}
+
+	ss := []*Structure{
+		dmiIDBIOS(read),
+		dmiIDSystem(read),
+		dmiIDBaseboard(read),
+		dmiIDChassis(read),
+	}
+
+	b, err := marshalStructures(ss)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ep := &DMIIDEntryPoint{
+		Path: dir,
+		Size: len(b),
+	}
+
+	return ioutil.NopCloser(bytes.NewReader(b)), ep, nil
+}
+
+// dmiIDBIOS synthesizes a BIOS Information structure.
+func dmiIDBIOS(read func(string) string) *Structure {
+	var sb stringSet
+	b := make([]byte, 0x18-headerLen)
+
+	b[0x04-headerLen] = sb.add(read("bios_vendor"))
+	b[0x05-headerLen] = sb.add(read("bios_version"))
+	b[0x08-headerLen] = sb.add(read("bios_date"))
+	b[0x0a-headerLen] = biosCharacteristicsNotSupported
+
+	b[0x14-headerLen], b[0x15-headerLen] = parseRelease(read("bios_release"))
+	b[0x16-headerLen], b[0x17-headerLen] = parseRelease(read("ec_firmware_release"))
+
+	return sb.structure(typeBIOS, 0, b)
+}
+
+// dmiIDSystem synthesizes a System Information structure.
+func dmiIDSystem(read func(string) string) *Structure {
+	var sb stringSet
+	b := make([]byte, 0x1b-headerLen)
+
+	b[0x04-headerLen] = sb.add(read("sys_vendor"))
+	b[0x05-headerLen] = sb.add(read("product_name"))
+	b[0x06-headerLen] = sb.add(read("product_version"))
+	b[0x07-headerLen] = sb.add(read("product_serial"))
+	copy(b[0x08-headerLen:0x18-headerLen], parseUUID(read("product_uuid")))
+	b[0x18-headerLen] = unknownEnum
+	b[0x19-headerLen] = sb.add(read("product_sku"))
+	b[0x1a-headerLen] = sb.add(read("product_family"))
+
+	return sb.structure(typeSystem, 1, b)
+}
+
+// dmiIDBaseboard synthesizes a Baseboard Information structure.
+func dmiIDBaseboard(read func(string) string) *Structure {
+	var sb stringSet
+	b := make([]byte, 0x0f-headerLen)
+
+	b[0x04-headerLen] = sb.add(read("board_vendor"))
+	b[0x05-headerLen] = sb.add(read("board_name"))
+	b[0x06-headerLen] = sb.add(read("board_version"))
+	b[0x07-headerLen] = sb.add(read("board_serial"))
+	b[0x08-headerLen] = sb.add(read("board_asset_tag"))
+
+	// The board is contained in the synthesized chassis.
+	binary.LittleEndian.PutUint16(b[0x0b-headerLen:0x0d-headerLen], 3)
+
+	// Board type "Unknown".
+	b[0x0d-headerLen] = 0x01
+
+	return sb.structure(typeBaseboard, 2, b)
+}
+
+// dmiIDChassis synthesizes a System Enclosure or Chassis structure.
+func dmiIDChassis(read func(string) string) *Structure {
+	var sb stringSet
+	b := make([]byte, 0x16-headerLen)
+
+	b[0x04-headerLen] = sb.add(read("chassis_vendor"))
+
+	typ := uint8(unknownEnum)
+	if v, err := strconv.ParseUint(read("chassis_type"), 10, 8); err == nil {
+		typ = uint8(v)
+	}
+	b[0x05-headerLen] = typ
+
+	b[0x06-headerLen] = sb.add(read("chassis_version"))
+	b[0x07-headerLen] = sb.add(read("chassis_serial"))
+	b[0x08-headerLen] = sb.add(read("chassis_asset_tag"))
+
+	// Boot-up, power supply, thermal, and security states.
+	for i := 0x09; i <= 0x0c; i++ {
+		b[i-headerLen] = unknownEnum
+	}
+
+	return sb.structure(typeChassis, 3, b)
+}
+
+// parseRelease parses a "major.minor" release string, returning values which
+// indicate the release is not supported if it cannot be parsed.
+func parseRelease(s string) (major, minor uint8) {
+	ss := strings.SplitN(s, ".", 2)
+	if len(ss) != 2 {
+		return unknownRelease, unknownRelease
+	}
+
+	ma, err := strconv.ParseUint(ss[0], 10, 8)
+	if err != nil {
+		return unknownRelease, unknownRelease
+	}
+	mi, err := strconv.ParseUint(ss[1], 10, 8)
+	if err != nil {
+		return unknownRelease, unknownRelease
+	}
+
+	return uint8(ma), uint8(mi)
+}
+
+// parseUUID parses a UUID string as printed by Linux into its SMBIOS 2.6+
+// binary form, in which the first three fields are little-endian.  If the
+// UUID cannot be parsed, it returns the all-zero "not present" UUID.
+func parseUUID(s string) []byte {
+	b, err := hex.DecodeString(strings.Replace(s, "-", "", -1))
+	if err != nil || len(b) != 16 {
+		return make([]byte, 16)
+	}
+
+	// Swap the time_low, time_mid, and time_hi_and_version fields.
+	b[0], b[1], b[2], b[3] = b[3], b[2], b[1], b[0]
+	b[4], b[5] = b[5], b[4]
+	b[6], b[7] = b[7], b[6]
+
+	return b
+}
+
+// A stringSet accumulates the strings for a synthesized Structure.
+type stringSet struct {
+	ss []string
+}
+
+// add adds s to the set and returns its string number, or 0 if s is empty
+// and no string should be referenced.
+func (sb *stringSet) add(s string) uint8 {
+	if s == "" {
+		return 0
+	}
+
+	sb.ss = append(sb.ss, s)
+	return uint8(len(sb.ss))
+}
+
+// structure creates a Structure using the strings in the set.
+func (sb *stringSet) structure(typ uint8, handle uint16, b []byte) *Structure {
+	return &Structure{
+		Header: Header{
+			Type:   typ,
+			Length: uint8(headerLen + len(b)),
+			Handle: handle,
+		},
+		Formatted: b,
+		Strings:   sb.ss,
+	}
+}
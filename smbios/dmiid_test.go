@@ -0,0 +1,135 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package smbios
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_dmiIDStream(t *testing.T) {
+	dir, err := ioutil.TempDir("", "smbios-test")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	id := filepath.Join(dir, "class", "dmi", "id")
+	if err := os.MkdirAll(id, 0755); err != nil {
+		t.Fatalf("failed to create DMI ID directory: %v", err)
+	}
+
+	// Privileged files such as product_serial are intentionally omitted.
+	files := map[string]string{
+		"bios_vendor":       "SeaBIOS\n",
+		"bios_version":      "1.13.0\n",
+		"bios_date":         "04/01/2014\n",
+		"bios_release":      "0.0\n",
+		"sys_vendor":        "QEMU\n",
+		"product_name":      "Standard PC (Q35 + ICH9, 2009)\n",
+		"product_version":   "pc-q35-5.0\n",
+		"product_uuid":      "01020304-0506-0708-090a-0b0c0d0e0f10\n",
+		"board_vendor":      "\n",
+		"chassis_type":      "1\n",
+		"chassis_vendor":    "QEMU\n",
+		"chassis_asset_tag": "\n",
+	}
+
+	for name, content := range files {
+		mustWriteFile(t, filepath.Join(id, name), []byte(content))
+	}
+
+	rc, ep, err := StreamWithOptions(&StreamOptions{
+		SysfsRoot:  dir,
+		Mechanisms: []Mechanism{MechanismSysfs, MechanismSysfsDMIID},
+	})
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	defer rc.Close()
+
+	if _, ok := ep.(*DMIIDEntryPoint); !ok {
+		t.Fatalf("expected DMI ID entry point, but got: %T", ep)
+	}
+
+	ss, err := NewDecoder(rc).Decode()
+	if err != nil {
+		t.Fatalf("failed to decode structures: %v", err)
+	}
+
+	if _, size := ep.Table(); size != 177 {
+		t.Fatalf("unexpected table size: %d", size)
+	}
+
+	want := []*Structure{
+		{
+			Header: Header{Type: 0, Length: 0x18, Handle: 0},
+			Formatted: []byte{
+				0x01, 0x02,
+				0x00, 0x00,
+				0x03,
+				0x00,
+				0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00,
+				0x00, 0x00,
+				0xff, 0xff,
+			},
+			Strings: []string{"SeaBIOS", "1.13.0", "04/01/2014"},
+		},
+		{
+			Header: Header{Type: 1, Length: 0x1b, Handle: 1},
+			Formatted: []byte{
+				0x01, 0x02, 0x03, 0x00,
+				0x04, 0x03, 0x02, 0x01, 0x06, 0x05, 0x08, 0x07,
+				0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+				0x02,
+				0x00, 0x00,
+			},
+			Strings: []string{"QEMU", "Standard PC (Q35 + ICH9, 2009)", "pc-q35-5.0"},
+		},
+		{
+			Header: Header{Type: 2, Length: 0x0f, Handle: 2},
+			Formatted: []byte{
+				0x00, 0x00, 0x00, 0x00, 0x00,
+				0x00,
+				0x00,
+				0x03, 0x00,
+				0x01,
+				0x00,
+			},
+		},
+		{
+			Header: Header{Type: 3, Length: 0x16, Handle: 3},
+			Formatted: []byte{
+				0x01, 0x01, 0x00, 0x00, 0x00,
+				0x02, 0x02, 0x02, 0x02,
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			Strings: []string{"QEMU"},
+		},
+		{
+			Header: Header{Type: 127, Length: 0x04, Handle: 4},
+		},
+	}
+
+	if diff := cmp.Diff(want, ss); diff != "" {
+		t.Fatalf("unexpected structures (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package smbios
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+// dumpTableOffset is the offset of the SMBIOS table in a dmidecode binary
+// dump file, immediately following the space reserved for the entry point.
+const dumpTableOffset = 0x20
+
+// OpenDump opens a stream of SMBIOS data and the SMBIOS entry point from a
+// file in the format produced by "dmidecode --dump-bin".  The stream must be
+// closed after decoding to free its resources.
+func OpenDump(name string) (io.ReadCloser, EntryPoint, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	return dumpStream(f)
+}
+
+// dumpStream reads the SMBIOS entry point and structure stream from an
+// io.ReadSeeker in dmidecode binary dump format.
+//
+// dumpStream is an entry point for tests.
+func dumpStream(rs io.ReadSeeker) (io.ReadCloser, EntryPoint, error) {
+	// The entry point is always stored at the beginning of the dump.
+	ep, err := ParseEntryPoint(rs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The entry point in the dump is rewritten by dmidecode to point to the
+	// offset of the table within the file.
+	tableAddr, tableSize := ep.Table()
+	if _, err := rs.Seek(int64(tableAddr), io.SeekStart); err != nil {
+		return nil, nil, err
+	}
+
+	out, err := ioutil.ReadAll(io.LimitReader(rs, int64(tableSize)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The 64-bit entry point only specifies a maximum size for the table,
+	// so the table may be shorter.
+	if _, ok := ep.(*EntryPoint64Bit); !ok && len(out) != tableSize {
+		return nil, nil, io.ErrUnexpectedEOF
+	}
+
+	return ioutil.NopCloser(bytes.NewReader(out)), ep, nil
+}
+
+// WriteDump reads an SMBIOS structure table from r and writes it to w in the
+// format produced by "dmidecode --dump-bin", along with an entry point which
+// is rewritten to point to the table's offset in the output.
+//
+// A WindowsEntryPoint does not contain enough information to produce a dump,
+// so an equivalent 32-bit or 64-bit entry point is created from the table's
+// contents instead.
+func WriteDump(w io.Writer, r io.Reader, ep EntryPoint) error {
+	table, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	var epb []byte
+	switch x := ep.(type) {
+	case *EntryPoint32Bit:
+		dep := *x
+		dep.StructureTableAddress = dumpTableOffset
+		epb, err = dep.MarshalBinary()
+	case *EntryPoint64Bit:
+		dep := *x
+		dep.StructureTableAddress = dumpTableOffset
+		epb, err = dep.MarshalBinary()
+	case *WindowsEntryPoint:
+		epb, err = dumpEntryPoint(x, table)
+	default:
+		return fmt.Errorf("cannot write SMBIOS dump for entry point type %T", ep)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Pad the entry point out to the beginning of the table.
+	b := make([]byte, dumpTableOffset, dumpTableOffset+len(table))
+	copy(b, epb)
+	b = append(b, table...)
+
+	_, err = w.Write(b)
+	return err
+}
+
+// dumpEntryPoint creates the binary form of an entry point for a dump which
+// is equivalent to a WindowsEntryPoint.
+func dumpEntryPoint(ep *WindowsEntryPoint, table []byte) ([]byte, error) {
+	ss, err := NewDecoder(bytes.NewReader(table)).Decode()
+	if err != nil {
+		return nil, err
+	}
+
+	// SMBIOS 3.0 and above use the 64-bit entry point.
+	if ep.MajorVersion >= 3 {
+		dep, err := NewEntryPoint64Bit(ep.MajorVersion, ep.MinorVersion, ep.Revision, dumpTableOffset, ss)
+		if err != nil {
+			return nil, err
+		}
+
+		return dep.MarshalBinary()
+	}
+
+	dep, err := NewEntryPoint32Bit(ep.MajorVersion, ep.MinorVersion, dumpTableOffset, ss)
+	if err != nil {
+		return nil, err
+	}
+
+	return dep.MarshalBinary()
+}
@@ -0,0 +1,156 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package smbios
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_dumpStream(t *testing.T) {
+	// Structure stream used to build all dumps.
+	stream := []byte{
+		0x00, 0x05, 0x01, 0x00,
+		0xff,
+		0x00,
+		0x00,
+
+		0x01, 0x0c, 0x02, 0x00,
+		0xde, 0xad, 0xbe, 0xef, 0xde, 0xad, 0xbe, 0xef,
+		'd', 'e', 'a', 'd', 'b', 'e', 'e', 'f', 0x00,
+		0x00,
+
+		127, 0x06, 0x03, 0x00,
+		0x01, 0x02,
+		'a', 'b', 'c', 'd', 0x00,
+		'1', '2', '3', '4', 0x00,
+		0x00,
+	}
+
+	want, err := NewDecoder(bytes.NewReader(stream)).Decode()
+	if err != nil {
+		t.Fatalf("failed to decode structures: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		ep   EntryPoint
+		b    []byte
+		ok   bool
+	}{
+		{
+			name: "empty",
+			b:    []byte{},
+		},
+		{
+			name: "32, short table",
+			b: func() []byte {
+				ep, err := NewEntryPoint32Bit(2, 8, dumpTableOffset, want)
+				if err != nil {
+					panic(err)
+				}
+
+				b := make([]byte, dumpTableOffset)
+				copy(b, mustMarshalEntryPoint(ep))
+
+				return append(b, stream[:10]...)
+			}(),
+		},
+		{
+			name: "32, OK",
+			ep: &EntryPoint32Bit{
+				Major:                 2,
+				Minor:                 8,
+				StructureTableLength:  uint16(len(stream)),
+				StructureTableAddress: 0x000f0000,
+				NumberStructures:      3,
+			},
+			ok: true,
+		},
+		{
+			name: "64, OK",
+			ep: &EntryPoint64Bit{
+				Major: 3,
+				// Maximum size may be larger than the actual table.
+				StructureTableMaxSize: 1024,
+				StructureTableAddress: 0x000f0000,
+			},
+			ok: true,
+		},
+		{
+			name: "Windows 2.x, OK",
+			ep: &WindowsEntryPoint{
+				Size:         uint32(len(stream)),
+				MajorVersion: 2,
+				MinorVersion: 4,
+			},
+			ok: true,
+		},
+		{
+			name: "Windows 3.x, OK",
+			ep: &WindowsEntryPoint{
+				Size:         uint32(len(stream)),
+				MajorVersion: 3,
+				MinorVersion: 2,
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := tt.b
+			if b == nil {
+				var buf bytes.Buffer
+				if err := WriteDump(&buf, bytes.NewReader(stream), tt.ep); err != nil {
+					t.Fatalf("failed to write dump: %v", err)
+				}
+
+				b = buf.Bytes()
+			}
+
+			rc, ep, err := dumpStream(bytes.NewReader(b))
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				// Don't bother doing comparison if dump is invalid.
+				t.Logf("OK error: %v", err)
+				return
+			}
+			defer rc.Close()
+
+			if addr, _ := ep.Table(); addr != dumpTableOffset {
+				t.Fatalf("unexpected table address in dump: %#x", addr)
+			}
+
+			ss, err := NewDecoder(rc).Decode()
+			if err != nil {
+				t.Fatalf("failed to decode structures: %v", err)
+			}
+
+			if diff := cmp.Diff(want, ss); diff != "" {
+				t.Fatalf("unexpected structures (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package smbios
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxStructureLen is the maximum length of a Structure's header and
+// formatted section, which must fit in Header.Length.
+const maxStructureLen = 0xff
+
+// An Encoder encodes Structures to a stream.
+type Encoder struct {
+	w io.Writer
+}
+
+// NewEncoder creates an Encoder which encodes Structures to the output stream.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{
+		w: w,
+	}
+}
+
+// Encode encodes Structures to the Encoder's stream as an SMBIOS structure
+// table.
+//
+// Each Structure's Header.Length is computed from the length of its formatted
+// section, and its string-set is terminated as required by the SMBIOS
+// specification.  If the final Structure is not an End-of-table structure,
+// one is appended using the handle following the highest handle in ss, and
+// an error is returned if the highest handle is 0xffff.
+func (e *Encoder) Encode(ss []*Structure) error {
+	b, err := marshalStructures(ss)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.w.Write(b)
+	return err
+}
+
+// marshalStructures produces the binary form of an SMBIOS structure table
+// containing ss, appending an End-of-table structure if necessary.
+func marshalStructures(ss []*Structure) ([]byte, error) {
+	var (
+		b   []byte
+		max uint16
+		eot bool
+	)
+
+	for _, s := range ss {
+		var err error
+		b, err = appendStructure(b, s)
+		if err != nil {
+			return nil, err
+		}
+
+		if s.Header.Handle > max {
+			max = s.Header.Handle
+		}
+
+		eot = s.Header.Type == typeEndOfTable
+	}
+
+	if !eot {
+		if max == 0xffff {
+			return nil, errors.New("cannot allocate handle for End-of-table structure: highest handle is 0xffff")
+		}
+
+		var err error
+		b, err = appendStructure(b, &Structure{
+			Header: Header{
+				Type:   typeEndOfTable,
+				Handle: max + 1,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+// appendStructure appends the binary form of s to b.
+func appendStructure(b []byte, s *Structure) ([]byte, error) {
+	l := headerLen + len(s.Formatted)
+	if l > maxStructureLen {
+		return nil, fmt.Errorf("formatted section of type %d structure with handle 0x%04x is too long: %d bytes",
+			s.Header.Type, s.Header.Handle, len(s.Formatted))
+	}
+
+	b = append(b, s.Header.Type, uint8(l), 0, 0)
+	binary.LittleEndian.PutUint16(b[len(b)-2:], s.Header.Handle)
+	b = append(b, s.Formatted...)
+
+	// If no string-set present, terminate with two null bytes.
+	if len(s.Strings) == 0 {
+		return append(b, endStringSet...), nil
+	}
+
+	for i, str := range s.Strings {
+		// Empty strings are encoded as a lone null, but an empty final string
+		// or strings with embedded nulls would terminate the string-set early.
+		if str == "" && i == len(s.Strings)-1 {
+			return nil, errors.New("cannot encode empty final string in structure string-set")
+		}
+		if strings.IndexByte(str, 0x00) != -1 {
+			return nil, fmt.Errorf("cannot encode string containing null byte in structure string-set: %q", str)
+		}
+
+		b = append(b, str...)
+		b = append(b, null...)
+	}
+
+	// Final string is followed by an additional null.
+	return append(b, null...), nil
+}
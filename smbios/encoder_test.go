@@ -0,0 +1,205 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package smbios_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEncoder(t *testing.T) {
+	tests := []struct {
+		name string
+		ss   []*smbios.Structure
+		b    []byte
+		ok   bool
+	}{
+		{
+			name: "formatted too long",
+			ss: []*smbios.Structure{{
+				Header:    smbios.Header{Type: 1},
+				Formatted: make([]byte, 252),
+			}},
+		},
+		{
+			name: "empty final string",
+			ss: []*smbios.Structure{{
+				Header:  smbios.Header{Type: 1},
+				Strings: []string{"abcd", ""},
+			}},
+		},
+		{
+			name: "string with null",
+			ss: []*smbios.Structure{{
+				Header:  smbios.Header{Type: 1},
+				Strings: []string{"ab\x00cd"},
+			}},
+		},
+		{
+			name: "no handle for end of table",
+			ss: []*smbios.Structure{{
+				Header: smbios.Header{
+					Type:   1,
+					Handle: 0xffff,
+				},
+			}},
+		},
+		{
+			name: "OK, no structures",
+			b: []byte{
+				127, 0x04, 0x01, 0x00,
+				0x00,
+				0x00,
+			},
+			ok: true,
+		},
+		{
+			name: "OK, append end of table",
+			ss: []*smbios.Structure{
+				{
+					Header: smbios.Header{
+						Type:   1,
+						Length: 0xff, // ignored
+						Handle: 2,
+					},
+					Formatted: []byte{0xde, 0xad, 0xbe, 0xef},
+					Strings:   []string{"deadbeef"},
+				},
+				{
+					Header: smbios.Header{
+						Type:   0,
+						Handle: 1,
+					},
+					Formatted: []byte{0xff},
+				},
+			},
+			b: []byte{
+				0x01, 0x08, 0x02, 0x00,
+				0xde, 0xad, 0xbe, 0xef,
+				'd', 'e', 'a', 'd', 'b', 'e', 'e', 'f', 0x00,
+				0x00,
+
+				0x00, 0x05, 0x01, 0x00,
+				0xff,
+				0x00,
+				0x00,
+
+				127, 0x04, 0x03, 0x00,
+				0x00,
+				0x00,
+			},
+			ok: true,
+		},
+		{
+			name: "OK, empty string",
+			ss: []*smbios.Structure{
+				{
+					Header: smbios.Header{
+						Type:   1,
+						Handle: 0xffff,
+					},
+					Strings: []string{"", "b"},
+				},
+				{
+					Header: smbios.Header{
+						Type:   127,
+						Handle: 0,
+					},
+				},
+			},
+			b: []byte{
+				0x01, 0x04, 0xff, 0xff,
+				0x00,
+				'b', 0x00,
+				0x00,
+
+				127, 0x04, 0x00, 0x00,
+				0x00,
+				0x00,
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := smbios.NewEncoder(&buf).Encode(tt.ss)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.b, buf.Bytes()); diff != "" {
+				t.Fatalf("unexpected bytes (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEncoderRoundTrip(t *testing.T) {
+	b := []byte{
+		0x00, 0x05, 0x01, 0x00,
+		0xff,
+		0x00,
+		0x00,
+
+		0x01, 0x0c, 0x02, 0x00,
+		0xde, 0xad, 0xbe, 0xef, 0xde, 0xad, 0xbe, 0xef,
+		'd', 'e', 'a', 'd', 'b', 'e', 'e', 'f', 0x00,
+		0x00,
+
+		0x02, 0x04, 0x03, 0x00,
+		'a', 0x00,
+		'b', 'c', 0x00,
+		0x00,
+
+		0x03, 0x04, 0x05, 0x00,
+		0x00,
+		'b', 0x00,
+		0x00,
+
+		127, 0x06, 0x06, 0x00,
+		0x01, 0x02,
+		'a', 'b', 'c', 'd', 0x00,
+		'1', '2', '3', '4', 0x00,
+		0x00,
+	}
+
+	ss, err := smbios.NewDecoder(bytes.NewReader(b)).Decode()
+	if err != nil {
+		t.Fatalf("failed to decode structures: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := smbios.NewEncoder(&buf).Encode(ss); err != nil {
+		t.Fatalf("failed to encode structures: %v", err)
+	}
+
+	if diff := cmp.Diff(b, buf.Bytes()); diff != "" {
+		t.Fatalf("unexpected bytes (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package smbios
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sysfsEntries is the location of the individual SMBIOS structures exposed by
+// Linux, relative to the sysfs root.
+var sysfsEntries = []string{"firmware", "dmi", "entries"}
+
+// ReadEntries reads the Structures of the specified types from the individual
+// entries exposed by Linux in /sys/firmware/dmi/entries, or the sysfs root
+// configured in opts.  If no types are specified, all Structures are read.
+// opts may be nil to use the defaults.
+//
+// Only the entries for the requested types are read, so ReadEntries avoids
+// reading and decoding the entire structure table.  Structures are returned
+// in the order in which they appear in the table, and are identical to those
+// returned by a Decoder.
+func ReadEntries(opts *StreamOptions, types ...uint8) ([]*Structure, error) {
+	o, err := opts.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := o.sysfsPath(sysfsEntries...)
+
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	want := make(map[uint8]bool, len(types))
+	for _, t := range types {
+		want[t] = true
+	}
+
+	type entry struct {
+		s   *Structure
+		pos int
+	}
+
+	var es []entry
+	for _, fi := range fis {
+		// Entries are named "<type>-<instance>".
+		typ, err := entryType(fi.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		if len(want) > 0 && !want[typ] {
+			continue
+		}
+
+		s, pos, err := readEntry(filepath.Join(dir, fi.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		es = append(es, entry{s: s, pos: pos})
+	}
+
+	sort.Slice(es, func(i, j int) bool {
+		return es[i].pos < es[j].pos
+	})
+
+	ss := make([]*Structure, 0, len(es))
+	for _, e := range es {
+		ss = append(ss, e.s)
+	}
+
+	return ss, nil
+}
+
+// entryType parses the type of a structure from its sysfs entry name.
+func entryType(name string) (uint8, error) {
+	ss := strings.SplitN(name, "-", 2)
+	if len(ss) != 2 {
+		return 0, fmt.Errorf("malformed SMBIOS sysfs entry name: %q", name)
+	}
+
+	t, err := strconv.ParseUint(ss[0], 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("malformed SMBIOS sysfs entry name %q: %v", name, err)
+	}
+
+	return uint8(t), nil
+}
+
+// readEntry reads a Structure and its position in the structure table from
+// a sysfs entry directory.
+func readEntry(dir string) (*Structure, int, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, "position"))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pos, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil {
+		return nil, 0, fmt.Errorf("malformed SMBIOS sysfs entry position in %q: %v", dir, err)
+	}
+
+	raw, err := ioutil.ReadFile(filepath.Join(dir, "raw"))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// The raw file contains exactly one structure, including its strings.
+	d := NewDecoder(bytes.NewReader(raw))
+	if !d.Next() {
+		return nil, 0, fmt.Errorf("failed to decode SMBIOS sysfs entry %q: %w", dir, d.Err())
+	}
+
+	return d.Structure(), pos, nil
+}
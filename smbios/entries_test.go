@@ -0,0 +1,155 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package smbios
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadEntries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "smbios-test")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	ss := []*Structure{
+		{
+			Header:    Header{Type: 0, Length: 5, Handle: 0},
+			Formatted: []byte{0x01},
+			Strings:   []string{"BIOS"},
+		},
+		{
+			Header:    Header{Type: 17, Length: 5, Handle: 1},
+			Formatted: []byte{0x01},
+			Strings:   []string{"DIMM 0"},
+		},
+		{
+			Header: Header{Type: 2, Length: 4, Handle: 2},
+		},
+		{
+			Header:    Header{Type: 17, Length: 6, Handle: 3},
+			Formatted: []byte{0x01, 0x02},
+			Strings:   []string{"DIMM 1", "Part"},
+		},
+		{
+			Header: Header{Type: 127, Length: 4, Handle: 4},
+		},
+	}
+
+	// Lay out the entries the way Linux does, tracking the instance number
+	// of each type.
+	entries := filepath.Join(dir, "firmware", "dmi", "entries")
+	instances := make(map[uint8]int)
+	for i, s := range ss {
+		name := fmt.Sprintf("%d-%d", s.Header.Type, instances[s.Header.Type])
+		instances[s.Header.Type]++
+
+		edir := filepath.Join(entries, name)
+		if err := os.MkdirAll(edir, 0755); err != nil {
+			t.Fatalf("failed to create entry directory: %v", err)
+		}
+
+		raw, err := appendStructure(nil, s)
+		if err != nil {
+			t.Fatalf("failed to marshal structure: %v", err)
+		}
+
+		mustWriteFile(t, filepath.Join(edir, "raw"), raw)
+		mustWriteFile(t, filepath.Join(edir, "position"), []byte(strconv.Itoa(i)+"\n"))
+	}
+
+	opts := &StreamOptions{SysfsRoot: dir}
+
+	tests := []struct {
+		name  string
+		types []uint8
+		ss    []*Structure
+	}{
+		{
+			name:  "none",
+			types: []uint8{4},
+			ss:    []*Structure{},
+		},
+		{
+			name:  "memory devices",
+			types: []uint8{17},
+			ss:    []*Structure{ss[1], ss[3]},
+		},
+		{
+			name:  "multiple types",
+			types: []uint8{127, 0},
+			ss:    []*Structure{ss[0], ss[4]},
+		},
+		{
+			name: "all",
+			ss:   ss,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadEntries(opts, tt.types...)
+			if err != nil {
+				t.Fatalf("failed to read entries: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.ss, got); diff != "" {
+				t.Fatalf("unexpected structures (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadEntriesDecodeError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "smbios-test")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	edir := filepath.Join(dir, "firmware", "dmi", "entries", "17-0")
+	if err := os.MkdirAll(edir, 0755); err != nil {
+		t.Fatalf("failed to create entry directory: %v", err)
+	}
+
+	// The header claims a longer formatted section than is present.
+	mustWriteFile(t, filepath.Join(edir, "raw"), []byte{17, 0x08, 0x02, 0x00, 0x01})
+	mustWriteFile(t, filepath.Join(edir, "position"), []byte("0\n"))
+
+	_, err = ReadEntries(&StreamOptions{SysfsRoot: dir})
+	if err == nil {
+		t.Fatal("expected an error, but none occurred")
+	}
+
+	var derr *DecodeError
+	if !errors.As(err, &derr) {
+		t.Fatalf("expected a *DecodeError, but got: %v", err)
+	}
+
+	if diff := cmp.Diff(uint16(2), derr.Header.Handle); diff != "" {
+		t.Fatalf("unexpected handle (-want +got):\n%s", diff)
+	}
+
+	t.Logf("OK error: %v", err)
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 )
 
 // Anchor strings used to detect entry points.
@@ -54,7 +55,7 @@ func ParseEntryPoint(r io.Reader) (EntryPoint, error) {
 	}
 
 	if l := len(b); l < 4 {
-		return nil, fmt.Errorf("too few bytes for SMBIOS entry point magic: %d", l)
+		return nil, fmt.Errorf("%w: too few bytes for SMBIOS entry point magic: %d", ErrInvalidEntryPoint, l)
 	}
 
 	switch {
@@ -64,7 +65,7 @@ func ParseEntryPoint(r io.Reader) (EntryPoint, error) {
 		return parse64(b)
 	}
 
-	return nil, fmt.Errorf("unrecognized SMBIOS entry point magic: %v", b[0:4])
+	return nil, fmt.Errorf("%w: %v", ErrUnknownEntryPoint, b[0:4])
 }
 
 var _ EntryPoint = &EntryPoint32Bit{}
@@ -98,38 +99,134 @@ func (e *EntryPoint32Bit) Version() (major, minor, revision int) {
 	return int(e.Major), int(e.Minor), 0
 }
 
+// MarshalBinary implements encoding.BinaryMarshaler.  The Anchor, Length,
+// IntermediateAnchor, and checksum fields are ignored and the correct values
+// are computed for the output.
+func (e *EntryPoint32Bit) MarshalBinary() ([]byte, error) {
+	b := make([]byte, expLen32)
+
+	copy(b[0:4], magic32)
+	b[5] = expLen32
+	b[6] = e.Major
+	b[7] = e.Minor
+	binary.LittleEndian.PutUint16(b[8:10], e.MaxStructureSize)
+	b[10] = e.EntryPointRevision
+	copy(b[11:16], e.FormattedArea[:])
+
+	copy(b[16:21], magicDMI)
+	binary.LittleEndian.PutUint16(b[22:24], e.StructureTableLength)
+	binary.LittleEndian.PutUint32(b[24:28], e.StructureTableAddress)
+	binary.LittleEndian.PutUint16(b[28:30], e.NumberStructures)
+	b[30] = e.BCDRevision
+
+	// The intermediate checksum must be computed first, since the outer
+	// checksum covers the entire entry point.
+	b[iEPIndex+5] = computeChecksum(5, b[iEPIndex:expLen32])
+	b[chkIndex32] = computeChecksum(chkIndex32, b)
+
+	return b, nil
+}
+
+const (
+	// expLen32 is the expected minimum length of a 32-bit entry point.
+	// Correct minimum length as of SMBIOS 3.1.1.
+	expLen32 = 31
+
+	// chkIndex32 is the index of the checksum byte in a 32-bit entry point.
+	chkIndex32 = 4
+
+	// iEPIndex is the index of the intermediate entry point within a 32-bit
+	// entry point.
+	iEPIndex = 16
+)
+
+// NewEntryPoint32Bit creates an EntryPoint32Bit for SMBIOS version
+// major.minor which describes a structure table at address addr containing
+// ss.  The table length, number of structures, and maximum structure size
+// are computed as if ss were encoded using an Encoder, and checksums are
+// filled in.
+func NewEntryPoint32Bit(major, minor uint8, addr uint32, ss []*Structure) (*EntryPoint32Bit, error) {
+	ti, err := newTableInfo(ss)
+	if err != nil {
+		return nil, err
+	}
+
+	if ti.length > math.MaxUint16 {
+		return nil, fmt.Errorf("SMBIOS table length %d is too large for a 32-bit entry point", ti.length)
+	}
+	if ti.count > math.MaxUint16 {
+		return nil, fmt.Errorf("SMBIOS structure count %d is too large for a 32-bit entry point", ti.count)
+	}
+
+	ep := &EntryPoint32Bit{
+		Anchor:                string(magic32),
+		Length:                expLen32,
+		Major:                 major,
+		Minor:                 minor,
+		MaxStructureSize:      uint16(ti.maxSize),
+		IntermediateAnchor:    string(magicDMI),
+		StructureTableLength:  uint16(ti.length),
+		StructureTableAddress: addr,
+		NumberStructures:      uint16(ti.count),
+	}
+
+	// The BCD revision can only represent single digit versions.
+	if major < 10 && minor < 10 {
+		ep.BCDRevision = major<<4 | minor
+	}
+
+	// Fill in the checksums so the EntryPoint matches what ParseEntryPoint
+	// would produce from its binary form.
+	b, err := ep.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	ep.Checksum = b[chkIndex32]
+	ep.IntermediateChecksum = b[iEPIndex+5]
+
+	return ep, nil
+}
+
 // parse32 parses an EntryPoint32Bit from b.
 func parse32(b []byte) (*EntryPoint32Bit, error) {
 	l := len(b)
 
-	// Correct minimum length as of SMBIOS 3.1.1.
-	const expLen = 31
-	if l < expLen {
-		return nil, fmt.Errorf("expected SMBIOS 32-bit entry point minimum length of at least %d, but got: %d", expLen, l)
+	// Ensure expected minimum length.
+	if l < expLen32 {
+		return nil, fmt.Errorf("%w: expected SMBIOS 32-bit entry point minimum length of at least %d, but got: %d", ErrInvalidEntryPoint, expLen32, l)
 	}
 
 	// Allow more data in the buffer than the actual length, for when the
 	// entry point is being read from system memory.
 	length := b[5]
 	if l < int(length) {
-		return nil, fmt.Errorf("expected SMBIOS 32-bit entry point actual length of at least %d, but got: %d", length, l)
+		return nil, fmt.Errorf("%w: expected SMBIOS 32-bit entry point actual length of at least %d, but got: %d", ErrInvalidEntryPoint, length, l)
 	}
 
 	// Look for intermediate anchor with DMI magic.
-	iAnchor := b[16:21]
+	iAnchor := b[iEPIndex : iEPIndex+len(magicDMI)]
 	if !bytes.Equal(iAnchor, magicDMI) {
-		return nil, fmt.Errorf("incorrect DMI magic in SMBIOS 32-bit entry point: %v", iAnchor)
+		return nil, fmt.Errorf("%w: incorrect DMI magic in SMBIOS 32-bit entry point: %v", ErrInvalidEntryPoint, iAnchor)
 	}
 
 	// Entry point checksum occurs at index 4, compute and verify it.
-	const epChkIndex = 4
-	epChk := b[epChkIndex]
-	if err := checksum(epChk, epChkIndex, b[:length]); err != nil {
+	epChk := b[chkIndex32]
+	if err := checksum(epChk, chkIndex32, b[:length]); err != nil {
 		return nil, err
 	}
 
-	// Since we already computed the checksum for the outer entry point,
-	// no real need to compute it for the intermediate entry point.
+	// Intermediate checksum occurs at index 5 of the intermediate entry
+	// point, which begins with the DMI magic.
+	const iChkIndex = 5
+	iChk := b[iEPIndex+iChkIndex]
+	if chk := computeChecksum(iChkIndex, b[iEPIndex:expLen32]); chk != iChk {
+		return nil, &ChecksumError{
+			Intermediate: true,
+			Expected:     chk,
+			Actual:       iChk,
+		}
+	}
 
 	ep := &EntryPoint32Bit{
 		Anchor:                string(b[0:4]),
@@ -146,7 +243,7 @@ func parse32(b []byte) (*EntryPoint32Bit, error) {
 		NumberStructures:      binary.LittleEndian.Uint16(b[28:30]),
 		BCDRevision:           b[30],
 	}
-	copy(ep.FormattedArea[:], b[10:15])
+	copy(ep.FormattedArea[:], b[11:16])
 
 	return ep, nil
 }
@@ -178,6 +275,64 @@ func (e *EntryPoint64Bit) Version() (major, minor, revision int) {
 	return int(e.Major), int(e.Minor), int(e.Revision)
 }
 
+// MarshalBinary implements encoding.BinaryMarshaler.  The Anchor, Length, and
+// Checksum fields are ignored and the correct values are computed for the
+// output.
+func (e *EntryPoint64Bit) MarshalBinary() ([]byte, error) {
+	b := make([]byte, expLen64)
+
+	copy(b[0:5], magic64)
+	b[6] = expLen64
+	b[7] = e.Major
+	b[8] = e.Minor
+	b[9] = e.Revision
+	b[10] = e.EntryPointRevision
+	b[11] = e.Reserved
+	binary.LittleEndian.PutUint32(b[12:16], e.StructureTableMaxSize)
+	binary.LittleEndian.PutUint64(b[16:24], e.StructureTableAddress)
+
+	b[chkIndex64] = computeChecksum(chkIndex64, b)
+
+	return b, nil
+}
+
+// NewEntryPoint64Bit creates an EntryPoint64Bit for SMBIOS version
+// major.minor.revision which describes a structure table at address addr
+// containing ss.  The table maximum size is computed as if ss were encoded
+// using an Encoder, and the checksum is filled in.
+func NewEntryPoint64Bit(major, minor, revision uint8, addr uint64, ss []*Structure) (*EntryPoint64Bit, error) {
+	ti, err := newTableInfo(ss)
+	if err != nil {
+		return nil, err
+	}
+
+	if ti.length > math.MaxUint32 {
+		return nil, fmt.Errorf("SMBIOS table length %d is too large for a 64-bit entry point", ti.length)
+	}
+
+	ep := &EntryPoint64Bit{
+		Anchor:   string(magic64),
+		Length:   expLen64,
+		Major:    major,
+		Minor:    minor,
+		Revision: revision,
+		// Entry point revision 1 is the only revision defined as of
+		// SMBIOS 3.1.1.
+		EntryPointRevision:    1,
+		StructureTableMaxSize: uint32(ti.length),
+		StructureTableAddress: addr,
+	}
+
+	b, err := ep.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	ep.Checksum = b[chkIndex64]
+
+	return ep, nil
+}
+
 const (
 	// expLen64 is the expected minimum length of a 64-bit entry point.
 	// Correct minimum length as of SMBIOS 3.1.1.
@@ -193,19 +348,19 @@ func parse64(b []byte) (*EntryPoint64Bit, error) {
 
 	// Ensure expected minimum length.
 	if l < expLen64 {
-		return nil, fmt.Errorf("expected SMBIOS 64-bit entry point minimum length of at least %d, but got: %d", expLen64, l)
+		return nil, fmt.Errorf("%w: expected SMBIOS 64-bit entry point minimum length of at least %d, but got: %d", ErrInvalidEntryPoint, expLen64, l)
 	}
 
 	// Allow more data in the buffer than the actual length, for when the
 	// entry point is being read from system memory.
 	length := b[6]
 	if l < int(length) {
-		return nil, fmt.Errorf("expected SMBIOS 64-bit entry point actual length of at least %d, but got: %d", length, l)
+		return nil, fmt.Errorf("%w: expected SMBIOS 64-bit entry point actual length of at least %d, but got: %d", ErrInvalidEntryPoint, length, l)
 	}
 
 	// Checksum occurs at index 5, compute and verify it.
 	chk := b[chkIndex64]
-	if err := checksum(chk, chkIndex64, b); err != nil {
+	if err := checksum(chk, chkIndex64, b[:length]); err != nil {
 		return nil, err
 	}
 
@@ -223,12 +378,25 @@ func parse64(b []byte) (*EntryPoint64Bit, error) {
 	}, nil
 }
 
-// checksum computes the checksum of b using the starting value of start, and
-// skipping the checksum byte which occurs at index chkIndex.
+// checksum verifies that the checksum value start, which occurs at index
+// chkIndex, is the correct checksum for b.
 //
 // checksum assumes that b has already had its bounds checked.
 func checksum(start uint8, chkIndex int, b []byte) error {
-	chk := start
+	if chk := computeChecksum(chkIndex, b); chk != start {
+		return &ChecksumError{
+			Expected: chk,
+			Actual:   start,
+		}
+	}
+
+	return nil
+}
+
+// computeChecksum computes the value of the checksum byte at index chkIndex
+// which causes the bytes of b to sum to zero.
+func computeChecksum(chkIndex int, b []byte) uint8 {
+	var chk uint8
 	for i := range b {
 		// Checksum computation does not include index of checksum byte.
 		if i == chkIndex {
@@ -238,11 +406,47 @@ func checksum(start uint8, chkIndex int, b []byte) error {
 		chk += b[i]
 	}
 
-	if chk != 0 {
-		return fmt.Errorf("invalid entry point checksum %#02x from initial checksum %#02x", chk, start)
+	return -chk
+}
+
+// tableInfo contains information about an encoded SMBIOS structure table.
+type tableInfo struct {
+	length, count, maxSize int
+}
+
+// newTableInfo computes tableInfo for ss as if ss were encoded using an
+// Encoder.
+func newTableInfo(ss []*Structure) (*tableInfo, error) {
+	var ti tableInfo
+	add := func(s *Structure) error {
+		b, err := appendStructure(nil, s)
+		if err != nil {
+			return err
+		}
+
+		ti.length += len(b)
+		ti.count++
+		if len(b) > ti.maxSize {
+			ti.maxSize = len(b)
+		}
+
+		return nil
 	}
 
-	return nil
+	for _, s := range ss {
+		if err := add(s); err != nil {
+			return nil, err
+		}
+	}
+
+	// Account for the End-of-table structure added by the Encoder.
+	if len(ss) == 0 || ss[len(ss)-1].Header.Type != typeEndOfTable {
+		if err := add(&Structure{Header: Header{Type: typeEndOfTable}}); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ti, nil
 }
 
 // WindowsEntryPoint contains SMBIOS Table entry point data returned from
@@ -16,6 +16,8 @@ package smbios_test
 
 import (
 	"bytes"
+	"encoding"
+	"errors"
 	"testing"
 
 	"github.com/digitalocean/go-smbios/smbios"
@@ -102,6 +104,25 @@ func TestParseEntryPoint(t *testing.T) {
 				0x00,
 			},
 		},
+		{
+			name: "32, bad intermediate checksum",
+			b: []byte{
+				'_', 'S', 'M', '_',
+				0xa3,
+				0x1f,
+				0x2,
+				0x8,
+				0xd4,
+				0x1, 0x0,
+				0x0, 0x0, 0x0, 0x0, 0x0,
+				'_', 'D', 'M', 'I', '_',
+				0x96, // off by one
+				0x5f, 0xf,
+				0x0, 0x90, 0xf0, 0x7a,
+				0x43, 0x0,
+				0x28,
+			},
+		},
 		{
 			name: "32, OK",
 			b: []byte{
@@ -307,3 +328,222 @@ func TestParseEntryPoint(t *testing.T) {
 		})
 	}
 }
+
+func TestEntryPointMarshalBinary(t *testing.T) {
+	tests := []struct {
+		name string
+		b    []byte
+	}{
+		{
+			name: "32",
+			b: []byte{
+				'_', 'S', 'M', '_',
+				0xa4,
+				0x1f,
+				0x2,
+				0x8,
+				0xd4,
+				0x1, 0x0,
+				0x0, 0x0, 0x0, 0x0, 0x0,
+				'_', 'D', 'M', 'I', '_',
+				0x95,
+				0x5f, 0xf,
+				0x0, 0x90, 0xf0, 0x7a,
+				0x43, 0x0,
+				0x28,
+			},
+		},
+		{
+			name: "64",
+			b: []byte{
+				'_', 'S', 'M', '3', '_',
+				0x86,
+				0x18,
+				0x3,
+				0x0,
+				0x0,
+				0x1,
+				0x0,
+				0x53, 0x9, 0x0, 0x0,
+				0xb0, 0xb3, 0xe, 0x0, 0x0, 0x0, 0x0, 0x0,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ep, err := smbios.ParseEntryPoint(bytes.NewReader(tt.b))
+			if err != nil {
+				t.Fatalf("failed to parse entry point: %v", err)
+			}
+
+			b, err := ep.(encoding.BinaryMarshaler).MarshalBinary()
+			if err != nil {
+				t.Fatalf("failed to marshal entry point: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.b, b); diff != "" {
+				t.Fatalf("unexpected entry point bytes (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewEntryPoint(t *testing.T) {
+	ss := []*smbios.Structure{
+		{
+			Header:    smbios.Header{Type: 0, Handle: 0},
+			Formatted: []byte{0x01, 0x02},
+			Strings:   []string{"abcd"},
+		},
+		{
+			Header: smbios.Header{Type: 1, Handle: 1},
+		},
+	}
+
+	ep32, err := smbios.NewEntryPoint32Bit(2, 8, 0x000f0000, ss)
+	if err != nil {
+		t.Fatalf("failed to create 32-bit entry point: %v", err)
+	}
+
+	ep64, err := smbios.NewEntryPoint64Bit(3, 2, 0, 0x000f0000, ss)
+	if err != nil {
+		t.Fatalf("failed to create 64-bit entry point: %v", err)
+	}
+
+	// Table contains 12 and 6 byte structures, plus a 6 byte End-of-table
+	// structure.
+	want32 := &smbios.EntryPoint32Bit{
+		Anchor:                "_SM_",
+		Length:                0x1f,
+		Major:                 2,
+		Minor:                 8,
+		MaxStructureSize:      12,
+		IntermediateAnchor:    "_DMI_",
+		StructureTableLength:  24,
+		StructureTableAddress: 0x000f0000,
+		NumberStructures:      3,
+		BCDRevision:           0x28,
+	}
+
+	want64 := &smbios.EntryPoint64Bit{
+		Anchor:                "_SM3_",
+		Length:                0x18,
+		Major:                 3,
+		Minor:                 2,
+		EntryPointRevision:    1,
+		StructureTableMaxSize: 24,
+		StructureTableAddress: 0x000f0000,
+	}
+
+	for _, ep := range []smbios.EntryPoint{ep32, ep64} {
+		b, err := ep.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Fatalf("failed to marshal entry point: %v", err)
+		}
+
+		// Parsing verifies the checksums, and the parsed entry point should
+		// be identical to the one created.
+		got, err := smbios.ParseEntryPoint(bytes.NewReader(b))
+		if err != nil {
+			t.Fatalf("failed to parse entry point: %v", err)
+		}
+
+		if diff := cmp.Diff(ep, got); diff != "" {
+			t.Fatalf("unexpected parsed entry point (-want +got):\n%s", diff)
+		}
+	}
+
+	// Ignore checksums, which were verified above.
+	ep32.Checksum, ep32.IntermediateChecksum, ep64.Checksum = 0, 0, 0
+
+	if diff := cmp.Diff(want32, ep32); diff != "" {
+		t.Fatalf("unexpected 32-bit entry point (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want64, ep64); diff != "" {
+		t.Fatalf("unexpected 64-bit entry point (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseEntryPointErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		b    []byte
+		is   error
+		chk  *smbios.ChecksumError
+	}{
+		{
+			name: "short magic",
+			b:    []byte{0x00},
+			is:   smbios.ErrInvalidEntryPoint,
+		},
+		{
+			name: "unknown magic",
+			b:    []byte{0xff, 0xff, 0xff, 0xff},
+			is:   smbios.ErrUnknownEntryPoint,
+		},
+		{
+			name: "64, bad checksum",
+			b: []byte{
+				'_', 'S', 'M', '3', '_',
+				0x00,
+				0x18,
+				0x00,
+				0x00,
+				0x00,
+				0x00,
+				0x00,
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			},
+			is: smbios.ErrInvalidEntryPoint,
+			chk: &smbios.ChecksumError{
+				Expected: 0x57,
+				Actual:   0x00,
+			},
+		},
+		{
+			name: "32, bad intermediate checksum",
+			b: []byte{
+				'_', 'S', 'M', '_',
+				0xa3,
+				0x1f,
+				0x2,
+				0x8,
+				0xd4,
+				0x1, 0x0,
+				0x0, 0x0, 0x0, 0x0, 0x0,
+				'_', 'D', 'M', 'I', '_',
+				0x96,
+				0x5f, 0xf,
+				0x0, 0x90, 0xf0, 0x7a,
+				0x43, 0x0,
+				0x28,
+			},
+			is: smbios.ErrInvalidEntryPoint,
+			chk: &smbios.ChecksumError{
+				Intermediate: true,
+				Expected:     0x95,
+				Actual:       0x96,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := smbios.ParseEntryPoint(bytes.NewReader(tt.b))
+			if !errors.Is(err, tt.is) {
+				t.Fatalf("expected error %v, but got: %v", tt.is, err)
+			}
+
+			var chk *smbios.ChecksumError
+			if !errors.As(err, &chk) {
+				chk = nil
+			}
+
+			if diff := cmp.Diff(tt.chk, chk); diff != "" {
+				t.Fatalf("unexpected checksum error (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
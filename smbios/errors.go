@@ -0,0 +1,99 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package smbios
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNoEntryPoint indicates that no SMBIOS entry point could be found.
+	ErrNoEntryPoint = errors.New("no SMBIOS entry point found")
+
+	// ErrUnknownEntryPoint indicates that an SMBIOS entry point begins with
+	// unrecognized magic.
+	ErrUnknownEntryPoint = errors.New("unrecognized SMBIOS entry point magic")
+
+	// ErrInvalidEntryPoint indicates that an SMBIOS entry point is malformed.
+	// A *ChecksumError also matches ErrInvalidEntryPoint when using
+	// errors.Is.
+	ErrInvalidEntryPoint = errors.New("invalid SMBIOS entry point")
+)
+
+var _ error = &ChecksumError{}
+
+// A ChecksumError indicates that an SMBIOS entry point's checksum is
+// incorrect.
+type ChecksumError struct {
+	// Intermediate reports whether the checksum of the intermediate entry
+	// point in a 32-bit entry point is incorrect, rather than the checksum
+	// of the entry point itself.
+	Intermediate bool
+
+	// Expected is the checksum value which would make the entry point
+	// valid, and Actual is the checksum value found in the entry point.
+	Expected, Actual uint8
+}
+
+// Error implements error.
+func (e *ChecksumError) Error() string {
+	name := "entry point"
+	if e.Intermediate {
+		name = "intermediate entry point"
+	}
+
+	return fmt.Sprintf("invalid %s checksum: expected %#02x, but got: %#02x", name, e.Expected, e.Actual)
+}
+
+// Is reports whether target is ErrInvalidEntryPoint, for use with errors.Is.
+func (e *ChecksumError) Is(target error) bool {
+	return target == ErrInvalidEntryPoint
+}
+
+var _ error = &DecodeError{}
+
+// A DecodeError indicates that a Structure could not be decoded from a
+// stream.  Err is the underlying error, which is io.ErrUnexpectedEOF if the
+// stream is truncated.
+type DecodeError struct {
+	// Offset is the byte offset in the stream where the Structure begins.
+	Offset int
+
+	// Index is the index of the Structure in the stream.
+	Index int
+
+	// Header is the Structure's Header, or nil if the Header itself could
+	// not be decoded.
+	Header *Header
+
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements error.
+func (e *DecodeError) Error() string {
+	if e.Header == nil {
+		return fmt.Sprintf("failed to decode structure %d at offset %d: %v", e.Index, e.Offset, e.Err)
+	}
+
+	return fmt.Sprintf("failed to decode structure %d (type %d, handle 0x%04x) at offset %d: %v",
+		e.Index, e.Header.Type, e.Header.Handle, e.Offset, e.Err)
+}
+
+// Unwrap returns the underlying error, for use with errors.Is and errors.As.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,318 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package eventlog decodes SMBIOS System Event Log (Type 15) structures and
+// reads the event log records they describe.
+package eventlog
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/axrayn/go-smbios/smbios"
+)
+
+// typeEventLog is the SMBIOS structure type for System Event Log.
+const typeEventLog = 15
+
+// An AccessMethod is the method used to access an event log area.
+type AccessMethod uint8
+
+// List of possible AccessMethod values.
+const (
+	AccessMethodIndexedIO8   AccessMethod = 0x00
+	AccessMethodIndexedIO8x2 AccessMethod = 0x01
+	AccessMethodIndexedIO16  AccessMethod = 0x02
+	AccessMethodMemoryMapped AccessMethod = 0x03
+	AccessMethodGPNV         AccessMethod = 0x04
+
+	// accessMethodOEM is the first of the OEM-specific access methods.
+	accessMethodOEM AccessMethod = 0x80
+)
+
+// String returns the string representation of an AccessMethod.
+func (m AccessMethod) String() string {
+	switch {
+	case m == AccessMethodIndexedIO8:
+		return "Indexed I/O, one 8-bit index port, one 8-bit data port"
+	case m == AccessMethodIndexedIO8x2:
+		return "Indexed I/O, two 8-bit index ports, one 8-bit data port"
+	case m == AccessMethodIndexedIO16:
+		return "Indexed I/O, one 16-bit index port, one 8-bit data port"
+	case m == AccessMethodMemoryMapped:
+		return "Memory-mapped physical 32-bit address"
+	case m == AccessMethodGPNV:
+		return "General-purpose non-volatile data functions"
+	case m >= accessMethodOEM:
+		return "OEM-specific"
+	default:
+		return fmt.Sprintf("AccessMethod(%d)", uint8(m))
+	}
+}
+
+// Variable data formats of event log records.
+const (
+	formatHandle              = 0x01
+	formatMultipleEvent       = 0x02
+	formatMultipleEventHandle = 0x03
+)
+
+var (
+	headerFormatList = map[int]string{
+		0x00: "No header",
+		0x01: "Type 1",
+	}
+
+	logTypeList = map[int]string{
+		0x01: "Single-bit ECC memory error",
+		0x02: "Multi-bit ECC memory error",
+		0x03: "Parity memory error",
+		0x04: "Bus time-out",
+		0x05: "I/O Channel Check",
+		0x06: "Software NMI",
+		0x07: "POST Memory Resize",
+		0x08: "POST Error",
+		0x09: "PCI Parity Error",
+		0x0a: "PCI System Error",
+		0x0b: "CPU Failure",
+		0x0c: "EISA FailSafe Timer time-out",
+		0x0d: "Correctable memory log disabled",
+		0x0e: "Logging disabled for a specific Event Type",
+		0x10: "System Limit Exceeded",
+		0x11: "Asynchronous hardware timer expired",
+		0x12: "System configuration information",
+		0x13: "Hard-disk information",
+		0x14: "System reconfigured",
+		0x15: "Uncorrectable CPU-complex error",
+		0x16: "Log Area Reset/Cleared",
+		0x17: "System boot",
+	}
+
+	dataFormatList = map[int]string{
+		0x00: "None",
+		0x01: "Handle",
+		0x02: "Multiple-Event",
+		0x03: "Multiple-Event Handle",
+		0x04: "POST Results Bitmap",
+		0x05: "System Management Type",
+		0x06: "Multiple-Event System Management Type",
+	}
+)
+
+// A Descriptor describes a type of record which may be stored in the event
+// log, and the format of the record's variable data.
+type Descriptor struct {
+	Type       string
+	DataFormat string
+
+	typ, format uint8
+}
+
+// EventLog Structure for containing System Event Log information
+//
+// Offsets and lengths are in bytes, relative to the start of the log area.
+type EventLog struct {
+	AreaLength          int
+	HeaderStartOffset   int
+	DataStartOffset     int
+	AccessMethod        AccessMethod
+	Valid               bool
+	Full                bool
+	ChangeToken         uint32
+	AccessMethodAddress uint32
+	HeaderFormat        string
+	Descriptors         []Descriptor
+}
+
+// Get Function to build a *EventLog struct object with all
+// the details from SMBIOS
+func (e *EventLog) Get(s *smbios.Structure) error {
+	if s.Header.Type != typeEventLog {
+		return fmt.Errorf("expected SMBIOS structure type %d, but got: %d", typeEventLog, s.Header.Type)
+	}
+
+	// Correct minimum length as of SMBIOS 2.0.
+	const minLen = 16
+	f := s.Formatted
+	if l := len(f); l < minLen {
+		return fmt.Errorf("expected SMBIOS system event log length of at least %d, but got: %d", minLen, l)
+	}
+
+	*e = EventLog{
+		AreaLength:          int(binary.LittleEndian.Uint16(f[0:2])),
+		HeaderStartOffset:   int(binary.LittleEndian.Uint16(f[2:4])),
+		DataStartOffset:     int(binary.LittleEndian.Uint16(f[4:6])),
+		AccessMethod:        AccessMethod(f[6]),
+		Valid:               f[7]&0x01 != 0,
+		Full:                f[7]&0x02 != 0,
+		ChangeToken:         binary.LittleEndian.Uint32(f[8:12]),
+		AccessMethodAddress: binary.LittleEndian.Uint32(f[12:16]),
+	}
+
+	// The header format and descriptors were added in SMBIOS 2.1.
+	if len(f) < 19 {
+		return nil
+	}
+	e.HeaderFormat = headerFormatList[int(f[16])]
+
+	n, size := int(f[17]), int(f[18])
+	if end := 19 + n*size; len(f) < end {
+		return fmt.Errorf("expected SMBIOS system event log length of at least %d for %d descriptors, but got: %d", end, n, len(f))
+	}
+
+	// Each descriptor is at least 2 bytes, so ignore any which are shorter.
+	if size < 2 {
+		return nil
+	}
+
+	for i := 0; i < n; i++ {
+		d := f[19+i*size:]
+		e.Descriptors = append(e.Descriptors, Descriptor{
+			Type:       logTypeList[int(d[0])],
+			DataFormat: dataFormatList[int(d[1])],
+			typ:        d[0],
+			format:     d[1],
+		})
+	}
+
+	return nil
+}
+
+// A Record is an entry read from the event log.
+//
+// Handle is the handle of the Structure the event refers to, or 0xFFFF if
+// the record does not refer to a Structure.  Count is the number of times
+// the event occurred, for records which use a multiple-event format.
+type Record struct {
+	Type      string
+	Processed bool
+	Time      time.Time
+	Handle    uint16
+	Count     uint32
+	Data      []byte
+}
+
+// endOfLog is the record type which indicates the end of the log.
+const endOfLog = 0xff
+
+// recordHeaderLen is the length of a record's type, length, and timestamp
+// fields.
+const recordHeaderLen = 8
+
+// ReadRecords reads and parses the records in a memory-mapped event log
+// area from r, which is usually system memory such as /dev/mem.
+//
+// Only the memory-mapped access method is supported.
+func (e *EventLog) ReadRecords(r io.ReaderAt) ([]Record, error) {
+	if e.AccessMethod != AccessMethodMemoryMapped {
+		return nil, fmt.Errorf("unsupported event log access method: %s", e.AccessMethod)
+	}
+
+	if e.DataStartOffset > e.AreaLength {
+		return nil, fmt.Errorf("event log data start offset %d exceeds area length %d", e.DataStartOffset, e.AreaLength)
+	}
+
+	// Make a copy of the log area so the records don't refer to system
+	// memory.
+	area := make([]byte, e.AreaLength)
+	sr := io.NewSectionReader(r, int64(e.AccessMethodAddress), int64(e.AreaLength))
+	if _, err := io.ReadFull(sr, area); err != nil {
+		return nil, err
+	}
+
+	return e.parseRecords(area[e.DataStartOffset:])
+}
+
+// parseRecords parses records from the data portion of an event log area.
+func (e *EventLog) parseRecords(b []byte) ([]Record, error) {
+	formats := make(map[uint8]uint8, len(e.Descriptors))
+	for _, d := range e.Descriptors {
+		formats[d.typ] = d.format
+	}
+
+	var records []Record
+	for off := 0; off < len(b); {
+		typ := b[off]
+		if typ == endOfLog {
+			break
+		}
+
+		if off+2 > len(b) {
+			return nil, fmt.Errorf("event log record at offset %d is truncated", off)
+		}
+
+		// The most significant bit of the length indicates whether the
+		// record has been processed by software.
+		l := int(b[off+1] & 0x7f)
+		if l < recordHeaderLen || off+l > len(b) {
+			return nil, fmt.Errorf("event log record at offset %d has invalid length: %d", off, l)
+		}
+
+		rb := b[off : off+l]
+		r := Record{
+			Type:      logTypeList[int(typ)],
+			Processed: rb[1]&0x80 != 0,
+			Time:      parseTime(rb[2:8]),
+			Handle:    0xffff,
+			Data:      rb[recordHeaderLen:],
+		}
+
+		data := r.Data
+		switch formats[typ] {
+		case formatHandle:
+			if len(data) >= 2 {
+				r.Handle = binary.LittleEndian.Uint16(data[0:2])
+			}
+		case formatMultipleEvent:
+			if len(data) >= 4 {
+				r.Count = binary.LittleEndian.Uint32(data[0:4])
+			}
+		case formatMultipleEventHandle:
+			if len(data) >= 6 {
+				r.Handle = binary.LittleEndian.Uint16(data[0:2])
+				r.Count = binary.LittleEndian.Uint32(data[2:6])
+			}
+		}
+
+		records = append(records, r)
+		off += l
+	}
+
+	return records, nil
+}
+
+// parseTime parses a record's BCD year, month, day, hour, minute, and
+// second fields.  It returns the zero time if the fields are not valid BCD.
+func parseTime(b []byte) time.Time {
+	var v [6]int
+	for i, x := range b[:6] {
+		hi, lo := int(x>>4), int(x&0x0f)
+		if hi > 9 || lo > 9 {
+			return time.Time{}
+		}
+
+		v[i] = hi*10 + lo
+	}
+
+	// Years 80 through 99 are in the 1900s, and all others are in the
+	// 2000s.
+	year := 2000 + v[0]
+	if v[0] >= 80 {
+		year = 1900 + v[0]
+	}
+
+	return time.Date(year, time.Month(v[1]), v[2], v[3], v[4], v[5], 0, time.UTC)
+}
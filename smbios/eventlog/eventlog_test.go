@@ -0,0 +1,255 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventlog_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/eventlog"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// eventLog returns the formatted area of an event log structure with a
+// memory-mapped log area of 0x40 bytes at address 0x100.
+func eventLog() []byte {
+	return []byte{
+		0x40, 0x00,
+		0x00, 0x00,
+		0x10, 0x00,
+		0x03,
+		0x01,
+		0x01, 0x00, 0x00, 0x00,
+		0x00, 0x01, 0x00, 0x00,
+		0x01,
+		// Three 2-byte descriptors.
+		0x03, 0x02,
+		0x01, 0x01,
+		0x17, 0x00,
+		0x08, 0x03,
+	}
+}
+
+func TestEventLogGet(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		e    *eventlog.EventLog
+		ok   bool
+	}{
+		{
+			name: "wrong type",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 16}},
+		},
+		{
+			name: "too short",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 15},
+				Formatted: eventLog()[:12],
+			},
+		},
+		{
+			name: "descriptors truncated",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 15},
+				Formatted: eventLog()[:23],
+			},
+		},
+		{
+			name: "OK, 2.0",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 15},
+				Formatted: eventLog()[:16],
+			},
+			e: &eventlog.EventLog{
+				AreaLength:          0x40,
+				DataStartOffset:     0x10,
+				AccessMethod:        eventlog.AccessMethodMemoryMapped,
+				Valid:               true,
+				ChangeToken:         1,
+				AccessMethodAddress: 0x100,
+			},
+			ok: true,
+		},
+		{
+			name: "OK, 2.1",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 15},
+				Formatted: eventLog(),
+			},
+			e: &eventlog.EventLog{
+				AreaLength:          0x40,
+				DataStartOffset:     0x10,
+				AccessMethod:        eventlog.AccessMethodMemoryMapped,
+				Valid:               true,
+				ChangeToken:         1,
+				AccessMethodAddress: 0x100,
+				HeaderFormat:        "Type 1",
+				Descriptors: []eventlog.Descriptor{
+					{Type: "Single-bit ECC memory error", DataFormat: "Handle"},
+					{Type: "System boot", DataFormat: "None"},
+					{Type: "POST Error", DataFormat: "Multiple-Event Handle"},
+				},
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e eventlog.EventLog
+			err := e.Get(tt.s)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.e, &e, cmpopts.IgnoreUnexported(eventlog.Descriptor{})); diff != "" {
+				t.Fatalf("unexpected event log (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEventLogReadRecords(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       []byte
+		records []byte
+		rs      []eventlog.Record
+		ok      bool
+	}{
+		{
+			name: "not memory-mapped",
+			f: func() []byte {
+				f := eventLog()
+				f[6] = 0x04
+				return f
+			}(),
+		},
+		{
+			name: "log area truncated",
+			f: func() []byte {
+				f := eventLog()
+				f[0] = 0xff
+				return f
+			}(),
+		},
+		{
+			name: "bad record length",
+			f:    eventLog(),
+			records: []byte{
+				0x17, 0x04, 0x24, 0x03,
+			},
+		},
+		{
+			name: "record overflows log area",
+			f:    eventLog(),
+			records: []byte{
+				0x17, 0x7f, 0x24, 0x03, 0x15, 0x10, 0x30, 0x45,
+			},
+		},
+		{
+			name:    "OK, empty",
+			f:       eventLog(),
+			records: []byte{0xff},
+			ok:      true,
+		},
+		{
+			name: "OK, records",
+			f:    eventLog(),
+			records: []byte{
+				// Single-bit ECC error with a handle.
+				0x01, 0x0a, 0x24, 0x03, 0x15, 0x10, 0x30, 0x45,
+				0x00, 0x11,
+				// Processed system boot.
+				0x17, 0x88, 0x99, 0x12, 0x31, 0x23, 0x59, 0x59,
+				// POST error with an invalid timestamp, handle, and count.
+				0x08, 0x0e, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+				0x00, 0x12,
+				0x05, 0x00, 0x00, 0x00,
+				0xff,
+			},
+			rs: []eventlog.Record{
+				{
+					Type:   "Single-bit ECC memory error",
+					Time:   time.Date(2024, time.March, 15, 10, 30, 45, 0, time.UTC),
+					Handle: 0x1100,
+					Data:   []byte{0x00, 0x11},
+				},
+				{
+					Type:      "System boot",
+					Processed: true,
+					Time:      time.Date(1999, time.December, 31, 23, 59, 59, 0, time.UTC),
+					Handle:    0xffff,
+					Data:      []byte{},
+				},
+				{
+					Type:   "POST Error",
+					Handle: 0x1200,
+					Count:  5,
+					Data:   []byte{0x00, 0x12, 0x05, 0x00, 0x00, 0x00},
+				},
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e eventlog.EventLog
+			if err := e.Get(&smbios.Structure{
+				Header:    smbios.Header{Type: 15},
+				Formatted: tt.f,
+			}); err != nil {
+				t.Fatalf("failed to decode event log: %v", err)
+			}
+
+			// Place the log area at its address in memory, with the
+			// records following the header.
+			mem := make([]byte, 0x140)
+			copy(mem[0x110:], tt.records)
+
+			rs, err := e.ReadRecords(bytes.NewReader(mem))
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.rs, rs); diff != "" {
+				t.Fatalf("unexpected records (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package group decodes SMBIOS Group Associations (Type 14) structures.
+package group
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/axrayn/go-smbios/smbios"
+)
+
+// typeGroup is the SMBIOS structure type for Group Associations.
+const typeGroup = 14
+
+// An Item is a member of a group, identified by its structure type and
+// handle.
+type Item struct {
+	Type   uint8
+	Handle uint16
+}
+
+// Group Structure for containing Group Associations information
+type Group struct {
+	Name  string
+	Items []Item
+}
+
+// Get Function to build a *Group struct object with all
+// the details from SMBIOS
+func (g *Group) Get(s *smbios.Structure) error {
+	if s.Header.Type != typeGroup {
+		return fmt.Errorf("expected SMBIOS structure type %d, but got: %d", typeGroup, s.Header.Type)
+	}
+
+	// The group name is followed by any number of 3 byte items.
+	f := s.Formatted
+	if l := len(f); l < 1 || (l-1)%3 != 0 {
+		return fmt.Errorf("expected SMBIOS group associations length of 1 plus a multiple of 3, but got: %d", l)
+	}
+
+	*g = Group{
+		Name: s.GetString(f[0]),
+	}
+
+	for i := 1; i < len(f); i += 3 {
+		g.Items = append(g.Items, Item{
+			Type:   f[i],
+			Handle: binary.LittleEndian.Uint16(f[i+1 : i+3]),
+		})
+	}
+
+	return nil
+}
+
+// Resolve returns the Structures in t which are members of the group, in
+// item order.  It returns an error if an item does not refer to a Structure
+// of the expected type in t.
+func (g *Group) Resolve(t *smbios.Table) ([]*smbios.Structure, error) {
+	ss := make([]*smbios.Structure, 0, len(g.Items))
+	for _, item := range g.Items {
+		s, ok := t.ByHandle(item.Handle)
+		if !ok {
+			return nil, fmt.Errorf("group %q item handle 0x%04x does not refer to a structure", g.Name, item.Handle)
+		}
+		if s.Header.Type != item.Type {
+			return nil, fmt.Errorf("group %q item handle 0x%04x expected SMBIOS structure type %d, but got: %d",
+				g.Name, item.Handle, item.Type, s.Header.Type)
+		}
+
+		ss = append(ss, s)
+	}
+
+	return ss, nil
+}
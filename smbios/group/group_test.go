@@ -0,0 +1,166 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group_test
+
+import (
+	"testing"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/group"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGroupGet(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		g    *group.Group
+		ok   bool
+	}{
+		{
+			name: "wrong type",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 13}},
+		},
+		{
+			name: "too short",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 14}},
+		},
+		{
+			name: "item truncated",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 14},
+				Formatted: []byte{
+					0x01,
+					0x04, 0x00, 0x04,
+					0x07, 0x00,
+				},
+			},
+		},
+		{
+			name: "OK, no items",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 14},
+				Formatted: []byte{0x01},
+				Strings:   []string{"Empty"},
+			},
+			g: &group.Group{
+				Name: "Empty",
+			},
+			ok: true,
+		},
+		{
+			name: "OK",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 14},
+				Formatted: []byte{
+					0x01,
+					0x04, 0x00, 0x04,
+					0x07, 0x00, 0x07,
+				},
+				Strings: []string{"CPU 0"},
+			},
+			g: &group.Group{
+				Name: "CPU 0",
+				Items: []group.Item{
+					{Type: 4, Handle: 0x0400},
+					{Type: 7, Handle: 0x0700},
+				},
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var g group.Group
+			err := g.Get(tt.s)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.g, &g); diff != "" {
+				t.Fatalf("unexpected group associations (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGroupResolve(t *testing.T) {
+	processor := &smbios.Structure{Header: smbios.Header{Type: 4, Handle: 0x0400}}
+	cache := &smbios.Structure{Header: smbios.Header{Type: 7, Handle: 0x0700}}
+	tbl := smbios.NewTableFromStructures([]*smbios.Structure{processor, cache}, nil)
+
+	tests := []struct {
+		name  string
+		items []group.Item
+		ss    []*smbios.Structure
+		ok    bool
+	}{
+		{
+			name: "missing handle",
+			items: []group.Item{
+				{Type: 4, Handle: 0x0400},
+				{Type: 7, Handle: 0x0701},
+			},
+		},
+		{
+			name: "type mismatch",
+			items: []group.Item{
+				{Type: 7, Handle: 0x0400},
+			},
+		},
+		{
+			name: "OK",
+			items: []group.Item{
+				{Type: 7, Handle: 0x0700},
+				{Type: 4, Handle: 0x0400},
+			},
+			ss: []*smbios.Structure{cache, processor},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := group.Group{Name: "CPU 0", Items: tt.items}
+			ss, err := g.Resolve(tbl)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.ss, ss); diff != "" {
+				t.Fatalf("unexpected group members (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package language decodes SMBIOS BIOS Language Information (Type 13)
+// structures.
+package language
+
+import (
+	"fmt"
+
+	"github.com/axrayn/go-smbios/smbios"
+)
+
+// typeLanguage is the SMBIOS structure type for BIOS Language Information.
+const typeLanguage = 13
+
+// Language Structure for containing BIOS Language information
+type Language struct {
+	Installable []string
+	Abbreviated bool
+	Current     string
+}
+
+// Get Function to build a *Language struct object with all
+// the details from SMBIOS
+func (l *Language) Get(s *smbios.Structure) error {
+	if s.Header.Type != typeLanguage {
+		return fmt.Errorf("expected SMBIOS structure type %d, but got: %d", typeLanguage, s.Header.Type)
+	}
+
+	const minLen = 18
+	f := s.Formatted
+	if n := len(f); n < minLen {
+		return fmt.Errorf("expected SMBIOS BIOS language length of at least %d, but got: %d", minLen, n)
+	}
+
+	n := int(f[0])
+	if c := len(s.Strings); c < n {
+		return fmt.Errorf("expected SMBIOS BIOS language with %d strings, but got: %d", n, c)
+	}
+
+	*l = Language{
+		// The abbreviated format flag was added in SMBIOS 2.1, and the byte
+		// was reserved and zero before then.
+		Abbreviated: f[1]&0x01 != 0,
+		Current:     s.GetString(f[17]),
+	}
+
+	for i := 1; i <= n; i++ {
+		l.Installable = append(l.Installable, s.GetString(uint8(i)))
+	}
+
+	return nil
+}
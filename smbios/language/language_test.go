@@ -0,0 +1,113 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package language_test
+
+import (
+	"testing"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/language"
+	"github.com/google/go-cmp/cmp"
+)
+
+// formatted returns the formatted area of a BIOS language structure with
+// the specified number of languages, flags, and current language.
+func formatted(n, flags, current byte) []byte {
+	f := make([]byte, 18)
+	f[0] = n
+	f[1] = flags
+	f[17] = current
+
+	return f
+}
+
+func TestLanguageGet(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		l    *language.Language
+		ok   bool
+	}{
+		{
+			name: "wrong type",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 14}},
+		},
+		{
+			name: "too short",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 13},
+				Formatted: []byte{0x01, 0x00},
+			},
+		},
+		{
+			name: "missing strings",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 13},
+				Formatted: formatted(3, 0x00, 1),
+				Strings:   []string{"en|US|iso8859-1", "fr|FR|iso8859-1"},
+			},
+		},
+		{
+			name: "OK, long format",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 13},
+				Formatted: formatted(2, 0x00, 2),
+				Strings:   []string{"en|US|iso8859-1", "fr|FR|iso8859-1"},
+			},
+			l: &language.Language{
+				Installable: []string{"en|US|iso8859-1", "fr|FR|iso8859-1"},
+				Current:     "fr|FR|iso8859-1",
+			},
+			ok: true,
+		},
+		{
+			name: "OK, abbreviated format",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 13},
+				Formatted: formatted(1, 0x01, 1),
+				Strings:   []string{"enUS"},
+			},
+			l: &language.Language{
+				Installable: []string{"enUS"},
+				Abbreviated: true,
+				Current:     "enUS",
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var l language.Language
+			err := l.Get(tt.s)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.l, &l); diff != "" {
+				t.Fatalf("unexpected BIOS language information (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	"github.com/axrayn/go-smbios/smbios"
+)
+
+// typePhysicalMemoryArray is the SMBIOS structure type for Physical Memory
+// Array.
+const typePhysicalMemoryArray = 16
+
+var (
+	locationList = map[int]string{
+		1:    "Other",
+		2:    "Unknown",
+		3:    "System board or motherboard",
+		4:    "ISA add-on card",
+		5:    "EISA add-on card",
+		6:    "PCI add-on card",
+		7:    "MCA add-on card",
+		8:    "PCMCIA add-on card",
+		9:    "Proprietary add-on card",
+		10:   "NuBus",
+		0xa0: "PC-98/C20 add-on card",
+		0xa1: "PC-98/C24 add-on card",
+		0xa2: "PC-98/E add-on card",
+		0xa3: "PC-98/Local bus add-on card",
+		0xa4: "CXL add-on card",
+	}
+
+	useList = map[int]string{
+		1: "Other",
+		2: "Unknown",
+		3: "System memory",
+		4: "Video memory",
+		5: "Flash memory",
+		6: "Non-volatile RAM",
+		7: "Cache memory",
+	}
+
+	errorCorrectionList = map[int]string{
+		1: "Other",
+		2: "Unknown",
+		3: "None",
+		4: "Parity",
+		5: "Single-bit ECC",
+		6: "Multi-bit ECC",
+		7: "CRC",
+	}
+)
+
+// Array Structure for containing Physical Memory Array information
+//
+// MaximumCapacity is in bytes.
+type Array struct {
+	Location                     string
+	Use                          string
+	ErrorCorrection              string
+	MaximumCapacity              uint64
+	MemoryErrorInformationHandle uint16
+	NumberOfDevices              int
+}
+
+// Get Function to build a *Array struct object with all
+// the details from SMBIOS
+func (a *Array) Get(s *smbios.Structure) error {
+	if s.Header.Type != typePhysicalMemoryArray {
+		return fmt.Errorf("expected SMBIOS structure type %d, but got: %d", typePhysicalMemoryArray, s.Header.Type)
+	}
+
+	// Correct minimum length as of SMBIOS 2.1.
+	const minLen = 11
+	f := s.Formatted
+	if l := len(f); l < minLen {
+		return fmt.Errorf("expected SMBIOS physical memory array length of at least %d, but got: %d", minLen, l)
+	}
+
+	*a = Array{
+		Location:                     locationList[int(f[0])],
+		Use:                          useList[int(f[1])],
+		ErrorCorrection:              errorCorrectionList[int(f[2])],
+		MemoryErrorInformationHandle: binary.LittleEndian.Uint16(f[7:9]),
+		NumberOfDevices:              int(binary.LittleEndian.Uint16(f[9:11])),
+	}
+
+	// Maximum capacity is in kilobytes, unless it is 0x80000000, in which
+	// case the extended maximum capacity in bytes from SMBIOS 2.7 is used.
+	capacity := binary.LittleEndian.Uint32(f[3:7])
+	if capacity != 0x80000000 {
+		a.MaximumCapacity = uint64(capacity) * kib
+		return nil
+	}
+
+	if len(f) < 19 {
+		return fmt.Errorf("expected SMBIOS physical memory array extended maximum capacity, but length is: %d", len(f))
+	}
+	a.MaximumCapacity = binary.LittleEndian.Uint64(f[11:19])
+
+	return nil
+}
+
+// An ArraySummary groups a Physical Memory Array with the Memory Devices
+// which belong to it.
+type ArraySummary struct {
+	Handle  uint16
+	Array   Array
+	Devices []Device
+}
+
+// Populated returns the number of devices installed in the array.
+func (s *ArraySummary) Populated() int {
+	var n int
+	for _, d := range s.Devices {
+		if d.Installed() {
+			n++
+		}
+	}
+
+	return n
+}
+
+// InstalledSize returns the total size in bytes of the devices installed in
+// the array.  Devices of unknown size are not counted.
+func (s *ArraySummary) InstalledSize() uint64 {
+	var size uint64
+	for _, d := range s.Devices {
+		if d.Size != UnknownSize {
+			size += d.Size
+		}
+	}
+
+	return size
+}
+
+// String returns a one line description of the array's capacity and
+// population.
+func (s *ArraySummary) String() string {
+	return fmt.Sprintf("array 0x%04x: %d of %d slots populated, installed %s GiB of max %s GiB, %s",
+		s.Handle,
+		s.Populated(),
+		s.Array.NumberOfDevices,
+		gib(s.InstalledSize()),
+		gib(s.Array.MaximumCapacity),
+		s.Array.ErrorCorrection,
+	)
+}
+
+// gib formats a size in bytes as gibibytes.
+func gib(size uint64) string {
+	return strconv.FormatFloat(float64(size)/(1<<30), 'f', -1, 64)
+}
+
+// Summarize decodes every Physical Memory Array in t and groups the Memory
+// Devices in t with the array they refer to by handle.  Summaries are
+// returned in table order.  Memory Devices which do not refer to an array
+// in t are not included.
+func Summarize(t *smbios.Table) ([]ArraySummary, error) {
+	var (
+		sums    []ArraySummary
+		indices = make(map[uint16]int)
+	)
+
+	for _, s := range t.ByType(typePhysicalMemoryArray) {
+		var a Array
+		if err := a.Get(s); err != nil {
+			return nil, err
+		}
+
+		// Use the first array if handles are duplicated, as Table does.
+		if _, ok := indices[s.Header.Handle]; ok {
+			continue
+		}
+
+		indices[s.Header.Handle] = len(sums)
+		sums = append(sums, ArraySummary{
+			Handle: s.Header.Handle,
+			Array:  a,
+		})
+	}
+
+	for _, s := range t.ByType(typeMemoryDevice) {
+		var d Device
+		if err := d.Get(s); err != nil {
+			return nil, err
+		}
+
+		i, ok := indices[d.PhysicalMemoryArrayHandle]
+		if !ok {
+			continue
+		}
+
+		sums[i].Devices = append(sums[i].Devices, d)
+	}
+
+	return sums, nil
+}
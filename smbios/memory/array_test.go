@@ -0,0 +1,216 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/memory"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestArrayGet(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		a    *memory.Array
+		ok   bool
+	}{
+		{
+			name: "wrong type",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 17}},
+		},
+		{
+			name: "too short",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 16},
+				Formatted: []byte{0x03, 0x03, 0x06},
+			},
+		},
+		{
+			name: "extended capacity missing",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 16},
+				Formatted: []byte{
+					0x03, 0x03, 0x06,
+					0x00, 0x00, 0x00, 0x80,
+					0xfe, 0xff,
+					0x04, 0x00,
+				},
+			},
+		},
+		{
+			name: "OK, 2.1",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 16},
+				Formatted: []byte{
+					0x03, 0x03, 0x03,
+					0x00, 0x00, 0x40, 0x00,
+					0xfe, 0xff,
+					0x02, 0x00,
+				},
+			},
+			a: &memory.Array{
+				Location:                     "System board or motherboard",
+				Use:                          "System memory",
+				ErrorCorrection:              "None",
+				MaximumCapacity:              4 << 30,
+				MemoryErrorInformationHandle: 0xfffe,
+				NumberOfDevices:              2,
+			},
+			ok: true,
+		},
+		{
+			name: "OK, 2.7, extended capacity",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 16},
+				Formatted: []byte{
+					0x03, 0x03, 0x06,
+					0x00, 0x00, 0x00, 0x80,
+					0x00, 0x20,
+					0x10, 0x00,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+				},
+			},
+			a: &memory.Array{
+				Location:                     "System board or motherboard",
+				Use:                          "System memory",
+				ErrorCorrection:              "Multi-bit ECC",
+				MaximumCapacity:              1 << 40,
+				MemoryErrorInformationHandle: 0x2000,
+				NumberOfDevices:              16,
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var a memory.Array
+			err := a.Get(tt.s)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.a, &a); diff != "" {
+				t.Fatalf("unexpected memory array (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	// array returns a physical memory array with the specified handle,
+	// maximum capacity in kilobytes, and number of devices.
+	array := func(handle uint16, capacity uint32, n uint8) *smbios.Structure {
+		return &smbios.Structure{
+			Header: smbios.Header{Type: 16, Handle: handle},
+			Formatted: []byte{
+				0x03, 0x03, 0x05,
+				byte(capacity), byte(capacity >> 8), byte(capacity >> 16), byte(capacity >> 24),
+				0xfe, 0xff,
+				n, 0x00,
+			},
+		}
+	}
+
+	// device returns a memory device in the array with the specified
+	// handle and size field.
+	device := func(handle, array, size uint16) *smbios.Structure {
+		return &smbios.Structure{
+			Header: smbios.Header{Type: 17, Handle: handle},
+			Formatted: []byte{
+				byte(array), byte(array >> 8),
+				0xfe, 0xff,
+				0x48, 0x00,
+				0x40, 0x00,
+				byte(size), byte(size >> 8),
+				0x09,
+				0x00,
+				0x01, 0x00,
+				0x1a,
+				0x80, 0x00,
+			},
+			Strings: []string{"DIMM"},
+		}
+	}
+
+	tbl := smbios.NewTableFromStructures([]*smbios.Structure{
+		array(0x1000, 0x10000000, 4),
+		device(0x1100, 0x1000, 0x4000),
+		device(0x1101, 0x1000, 0x0000),
+		device(0x1102, 0x1000, 0x2000),
+		device(0x1103, 0x1000, 0xffff),
+		array(0x2000, 0x00200000, 1),
+		device(0x2100, 0x2000, 0x8200),
+		// Refers to an array which does not exist.
+		device(0x3100, 0x3000, 0x4000),
+	}, nil)
+
+	sums, err := memory.Summarize(tbl)
+	if err != nil {
+		t.Fatalf("failed to summarize: %v", err)
+	}
+
+	type summary struct {
+		Handle    uint16
+		Devices   int
+		Populated int
+		Installed uint64
+		String    string
+	}
+
+	var got []summary
+	for _, s := range sums {
+		got = append(got, summary{
+			Handle:    s.Handle,
+			Devices:   len(s.Devices),
+			Populated: s.Populated(),
+			Installed: s.InstalledSize(),
+			String:    s.String(),
+		})
+	}
+
+	want := []summary{
+		{
+			Handle:    0x1000,
+			Devices:   4,
+			Populated: 3,
+			Installed: 24 << 30,
+			String:    "array 0x1000: 3 of 4 slots populated, installed 24 GiB of max 256 GiB, Single-bit ECC",
+		},
+		{
+			Handle:    0x2000,
+			Devices:   1,
+			Populated: 1,
+			Installed: 512 << 10,
+			String:    "array 0x2000: 1 of 1 slots populated, installed 0.00048828125 GiB of max 2 GiB, Single-bit ECC",
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected summaries (-want +got):\n%s", diff)
+	}
+}
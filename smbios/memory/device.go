@@ -0,0 +1,341 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package memory decodes SMBIOS memory structures, such as Memory Device
+// (Type 17) structures.
+package memory
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/axrayn/go-smbios/smbios"
+)
+
+// typeMemoryDevice is the SMBIOS structure type for Memory Device.
+const typeMemoryDevice = 17
+
+// UnknownSize is used for sizes which are reported as unknown.
+const UnknownSize = ^uint64(0)
+
+// Byte multiples used to normalize sizes.
+const (
+	kib = 1 << 10
+	mib = 1 << 20
+)
+
+var (
+	formFactorList = map[int]string{
+		1:  "Other",
+		2:  "Unknown",
+		3:  "SIMM",
+		4:  "SIP",
+		5:  "Chip",
+		6:  "DIP",
+		7:  "ZIP",
+		8:  "Proprietary Card",
+		9:  "DIMM",
+		10: "TSOP",
+		11: "Row of chips",
+		12: "RIMM",
+		13: "SODIMM",
+		14: "SRIMM",
+		15: "FB-DIMM",
+		16: "Die",
+		17: "CAMM",
+	}
+
+	memoryTypeList = map[int]string{
+		1:  "Other",
+		2:  "Unknown",
+		3:  "DRAM",
+		4:  "EDRAM",
+		5:  "VRAM",
+		6:  "SRAM",
+		7:  "RAM",
+		8:  "ROM",
+		9:  "FLASH",
+		10: "EEPROM",
+		11: "FEPROM",
+		12: "EPROM",
+		13: "CDRAM",
+		14: "3DRAM",
+		15: "SDRAM",
+		16: "SGRAM",
+		17: "RDRAM",
+		18: "DDR",
+		19: "DDR2",
+		20: "DDR2 FB-DIMM",
+		24: "DDR3",
+		25: "FBD2",
+		26: "DDR4",
+		27: "LPDDR",
+		28: "LPDDR2",
+		29: "LPDDR3",
+		30: "LPDDR4",
+		31: "Logical non-volatile device",
+		32: "HBM",
+		33: "HBM2",
+		34: "DDR5",
+		35: "LPDDR5",
+		36: "HBM3",
+	}
+
+	// typeDetailList is indexed by bit number.
+	typeDetailList = []string{
+		"Reserved",
+		"Other",
+		"Unknown",
+		"Fast-paged",
+		"Static column",
+		"Pseudo-static",
+		"RAMBUS",
+		"Synchronous",
+		"CMOS",
+		"EDO",
+		"Window DRAM",
+		"Cache DRAM",
+		"Non-volatile",
+		"Registered (Buffered)",
+		"Unbuffered (Unregistered)",
+		"LRDIMM",
+	}
+
+	memoryTechnologyList = map[int]string{
+		1: "Other",
+		2: "Unknown",
+		3: "DRAM",
+		4: "NVDIMM-N",
+		5: "NVDIMM-F",
+		6: "NVDIMM-P",
+		7: "Intel Optane persistent memory",
+	}
+
+	// operatingModeList is indexed by bit number.
+	operatingModeList = []string{
+		"Reserved",
+		"Other",
+		"Unknown",
+		"Volatile memory",
+		"Byte-accessible persistent memory",
+		"Block-accessible persistent memory",
+	}
+)
+
+// Device Structure for containing Memory Device information
+//
+// Sizes are in bytes, speeds are in megatransfers per second, and voltages
+// are in millivolts.  Sizes use UnknownSize when they are reported as
+// unknown, and other numeric fields are 0 when they are unknown.
+type Device struct {
+	PhysicalMemoryArrayHandle         uint16
+	MemoryErrorInformationHandle      uint16
+	TotalWidth                        int
+	DataWidth                         int
+	Size                              uint64
+	FormFactor                        string
+	DeviceSet                         int
+	DeviceLocator                     string
+	BankLocator                       string
+	MemoryType                        string
+	TypeDetail                        []string
+	Speed                             int
+	Manufacturer                      string
+	SerialNumber                      string
+	AssetTag                          string
+	PartNumber                        string
+	Rank                              int
+	ConfiguredSpeed                   int
+	MinimumVoltage                    int
+	MaximumVoltage                    int
+	ConfiguredVoltage                 int
+	MemoryTechnology                  string
+	OperatingModeCapability           []string
+	FirmwareVersion                   string
+	ModuleManufacturerID              uint16
+	ModuleProductID                   uint16
+	SubsystemControllerManufacturerID uint16
+	SubsystemControllerProductID      uint16
+	NonVolatileSize                   uint64
+	VolatileSize                      uint64
+	CacheSize                         uint64
+	LogicalSize                       uint64
+	PMIC0ManufacturerID               uint16
+	PMIC0RevisionNumber               uint16
+	RCDManufacturerID                 uint16
+	RCDRevisionNumber                 uint16
+}
+
+// Installed reports whether a memory device is installed in the socket.
+func (d *Device) Installed() bool {
+	return d.Size != 0
+}
+
+func getFlags(val uint16, list []string) (flags []string) {
+	for bit, name := range list {
+		if val&(1<<uint(bit)) != 0 {
+			flags = append(flags, name)
+		}
+	}
+	return flags
+}
+
+// getWidth returns a width in bits, or 0 if it is unknown.
+func getWidth(val uint16) int {
+	if val == 0xffff {
+		return 0
+	}
+	return int(val)
+}
+
+// getSize returns the size of a device in bytes from the size and extended
+// size fields.
+func getSize(val uint16, ext uint32) uint64 {
+	switch val {
+	case 0xffff:
+		return UnknownSize
+	case 0x7fff:
+		// Extended size is in megabytes, and its most significant bit is
+		// reserved.
+		return uint64(ext&0x7fffffff) * mib
+	}
+
+	// The most significant bit selects kilobyte granularity, otherwise
+	// megabytes.
+	if val&0x8000 != 0 {
+		return uint64(val&0x7fff) * kib
+	}
+	return uint64(val) * mib
+}
+
+// getSpeed returns a speed from the speed and extended speed fields, or 0
+// if it is unknown.
+func getSpeed(val uint16, ext uint32) int {
+	if val == 0xffff {
+		// Extended speed's most significant bit is reserved.
+		return int(ext & 0x7fffffff)
+	}
+	return int(val)
+}
+
+// getLargeSize returns a size in bytes from a QWORD size field, which uses
+// all bits set to indicate an unknown size.
+func getLargeSize(b []byte) uint64 {
+	return binary.LittleEndian.Uint64(b)
+}
+
+// Get Function to build a *Device struct object with all
+// the details from SMBIOS
+func (d *Device) Get(s *smbios.Structure) error {
+	if s.Header.Type != typeMemoryDevice {
+		return fmt.Errorf("expected SMBIOS structure type %d, but got: %d", typeMemoryDevice, s.Header.Type)
+	}
+
+	// Correct minimum length as of SMBIOS 2.1.
+	const minLen = 17
+	f := s.Formatted
+	if l := len(f); l < minLen {
+		return fmt.Errorf("expected SMBIOS memory device length of at least %d, but got: %d", minLen, l)
+	}
+
+	*d = Device{
+		PhysicalMemoryArrayHandle:    binary.LittleEndian.Uint16(f[0:2]),
+		MemoryErrorInformationHandle: binary.LittleEndian.Uint16(f[2:4]),
+		TotalWidth:                   getWidth(binary.LittleEndian.Uint16(f[4:6])),
+		DataWidth:                    getWidth(binary.LittleEndian.Uint16(f[6:8])),
+		FormFactor:                   formFactorList[int(f[10])],
+		DeviceLocator:                s.GetString(f[12]),
+		BankLocator:                  s.GetString(f[13]),
+		MemoryType:                   memoryTypeList[int(f[14])],
+		TypeDetail:                   getFlags(binary.LittleEndian.Uint16(f[15:17]), typeDetailList),
+	}
+
+	// Device set 0xFF is unknown; 0 indicates the device is not part of a set.
+	if f[11] != 0xff {
+		d.DeviceSet = int(f[11])
+	}
+
+	// The extended size is only present in SMBIOS 2.7 and later.
+	var extSize uint32
+	if len(f) >= 28 {
+		extSize = binary.LittleEndian.Uint32(f[24:28])
+	}
+	d.Size = getSize(binary.LittleEndian.Uint16(f[8:10]), extSize)
+
+	// Speed and strings were added in SMBIOS 2.3.
+	if len(f) < 23 {
+		return nil
+	}
+
+	// The extended speeds are only present in SMBIOS 3.3 and later.
+	var extSpeed, extConfSpeed uint32
+	if len(f) >= 88 {
+		extSpeed = binary.LittleEndian.Uint32(f[80:84])
+		extConfSpeed = binary.LittleEndian.Uint32(f[84:88])
+	}
+
+	d.Speed = getSpeed(binary.LittleEndian.Uint16(f[17:19]), extSpeed)
+	d.Manufacturer = s.GetString(f[19])
+	d.SerialNumber = s.GetString(f[20])
+	d.AssetTag = s.GetString(f[21])
+	d.PartNumber = s.GetString(f[22])
+
+	// Attributes were added in SMBIOS 2.6.
+	if len(f) < 24 {
+		return nil
+	}
+	d.Rank = int(f[23] & 0x0f)
+
+	// Configured speed was added in SMBIOS 2.7.
+	if len(f) < 30 {
+		return nil
+	}
+	d.ConfiguredSpeed = getSpeed(binary.LittleEndian.Uint16(f[28:30]), extConfSpeed)
+
+	// Voltages were added in SMBIOS 2.8.
+	if len(f) < 36 {
+		return nil
+	}
+	d.MinimumVoltage = int(binary.LittleEndian.Uint16(f[30:32]))
+	d.MaximumVoltage = int(binary.LittleEndian.Uint16(f[32:34]))
+	d.ConfiguredVoltage = int(binary.LittleEndian.Uint16(f[34:36]))
+
+	// Memory technology and persistent memory information were added in
+	// SMBIOS 3.2.
+	if len(f) < 80 {
+		return nil
+	}
+	d.MemoryTechnology = memoryTechnologyList[int(f[36])]
+	d.OperatingModeCapability = getFlags(binary.LittleEndian.Uint16(f[37:39]), operatingModeList)
+	d.FirmwareVersion = s.GetString(f[39])
+	d.ModuleManufacturerID = binary.LittleEndian.Uint16(f[40:42])
+	d.ModuleProductID = binary.LittleEndian.Uint16(f[42:44])
+	d.SubsystemControllerManufacturerID = binary.LittleEndian.Uint16(f[44:46])
+	d.SubsystemControllerProductID = binary.LittleEndian.Uint16(f[46:48])
+	d.NonVolatileSize = getLargeSize(f[48:56])
+	d.VolatileSize = getLargeSize(f[56:64])
+	d.CacheSize = getLargeSize(f[64:72])
+	d.LogicalSize = getLargeSize(f[72:80])
+
+	// PMIC and RCD information were added in SMBIOS 3.7.
+	if len(f) < 96 {
+		return nil
+	}
+	d.PMIC0ManufacturerID = binary.LittleEndian.Uint16(f[88:90])
+	d.PMIC0RevisionNumber = binary.LittleEndian.Uint16(f[90:92])
+	d.RCDManufacturerID = binary.LittleEndian.Uint16(f[92:94])
+	d.RCDRevisionNumber = binary.LittleEndian.Uint16(f[94:96])
+
+	return nil
+}
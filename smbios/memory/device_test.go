@@ -0,0 +1,265 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/memory"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDeviceGet(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		d    *memory.Device
+		ok   bool
+	}{
+		{
+			name: "wrong type",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 16}},
+		},
+		{
+			name: "too short",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 17},
+				Formatted: []byte{0x00, 0x10, 0xfe, 0xff},
+			},
+		},
+		{
+			name: "OK, 2.1, empty",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 17},
+				Formatted: []byte{
+					0x00, 0x10,
+					0xfe, 0xff,
+					0xff, 0xff,
+					0xff, 0xff,
+					0x00, 0x00,
+					0x09,
+					0x00,
+					0x01, 0x02,
+					0x02,
+					0x04, 0x00,
+				},
+				Strings: []string{"DIMM 0", "BANK 0"},
+			},
+			d: &memory.Device{
+				PhysicalMemoryArrayHandle:    0x1000,
+				MemoryErrorInformationHandle: 0xfffe,
+				FormFactor:                   "DIMM",
+				DeviceLocator:                "DIMM 0",
+				BankLocator:                  "BANK 0",
+				MemoryType:                   "Unknown",
+				TypeDetail:                   []string{"Unknown"},
+			},
+			ok: true,
+		},
+		{
+			name: "OK, 2.3, kilobytes",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 17},
+				Formatted: []byte{
+					0x00, 0x10,
+					0xfe, 0xff,
+					0x40, 0x00,
+					0x40, 0x00,
+					0x00, 0x82,
+					0x0d,
+					0xff,
+					0x01, 0x00,
+					0x0f,
+					0x80, 0x00,
+					0x85, 0x00,
+					0x02, 0x03, 0x00, 0x04,
+				},
+				Strings: []string{"SO-DIMM", "Acme", "S1234", "P5678"},
+			},
+			d: &memory.Device{
+				PhysicalMemoryArrayHandle:    0x1000,
+				MemoryErrorInformationHandle: 0xfffe,
+				TotalWidth:                   64,
+				DataWidth:                    64,
+				Size:                         512 * 1024,
+				FormFactor:                   "SODIMM",
+				DeviceLocator:                "SO-DIMM",
+				MemoryType:                   "SDRAM",
+				TypeDetail:                   []string{"Synchronous"},
+				Speed:                        133,
+				Manufacturer:                 "Acme",
+				SerialNumber:                 "S1234",
+				PartNumber:                   "P5678",
+			},
+			ok: true,
+		},
+		{
+			name: "OK, 2.8, extended size",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 17},
+				Formatted: []byte{
+					0x00, 0x10,
+					0xfe, 0xff,
+					0x48, 0x00,
+					0x40, 0x00,
+					0xff, 0x7f,
+					0x09,
+					0x00,
+					0x01, 0x02,
+					0x1a,
+					0x80, 0x20,
+					0x60, 0x09,
+					0x03, 0x04, 0x05, 0x06,
+					0x02,
+					0x00, 0x80, 0x00, 0x80,
+					0x40, 0x0b,
+					0xb0, 0x04,
+					0xb0, 0x04,
+					0xb0, 0x04,
+				},
+				Strings: []string{"DIMM_A1", "BANK 0", "Acme", "S1234", "A1234", "P5678"},
+			},
+			d: &memory.Device{
+				PhysicalMemoryArrayHandle:    0x1000,
+				MemoryErrorInformationHandle: 0xfffe,
+				TotalWidth:                   72,
+				DataWidth:                    64,
+				Size:                         32 << 30,
+				FormFactor:                   "DIMM",
+				DeviceLocator:                "DIMM_A1",
+				BankLocator:                  "BANK 0",
+				MemoryType:                   "DDR4",
+				TypeDetail:                   []string{"Synchronous", "Registered (Buffered)"},
+				Speed:                        2400,
+				Manufacturer:                 "Acme",
+				SerialNumber:                 "S1234",
+				AssetTag:                     "A1234",
+				PartNumber:                   "P5678",
+				Rank:                         2,
+				ConfiguredSpeed:              2880,
+				MinimumVoltage:               1200,
+				MaximumVoltage:               1200,
+				ConfiguredVoltage:            1200,
+			},
+			ok: true,
+		},
+		{
+			name: "OK, 3.7",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 17},
+				Formatted: device37(),
+				Strings:   []string{"DIMM_A1", "FW1"},
+			},
+			d: &memory.Device{
+				PhysicalMemoryArrayHandle:         0x1000,
+				MemoryErrorInformationHandle:      0xfffe,
+				TotalWidth:                        80,
+				DataWidth:                         64,
+				Size:                              64 << 30,
+				FormFactor:                        "DIMM",
+				DeviceSet:                         1,
+				DeviceLocator:                     "DIMM_A1",
+				MemoryType:                        "DDR5",
+				TypeDetail:                        []string{"Synchronous", "Registered (Buffered)"},
+				Speed:                             70000,
+				Rank:                              1,
+				ConfiguredSpeed:                   4800,
+				MinimumVoltage:                    1100,
+				MaximumVoltage:                    1100,
+				ConfiguredVoltage:                 1100,
+				MemoryTechnology:                  "DRAM",
+				OperatingModeCapability:           []string{"Volatile memory"},
+				FirmwareVersion:                   "FW1",
+				ModuleManufacturerID:              0xce80,
+				ModuleProductID:                   0x0001,
+				SubsystemControllerManufacturerID: 0x3206,
+				SubsystemControllerProductID:      0x0002,
+				VolatileSize:                      64 << 30,
+				CacheSize:                         memory.UnknownSize,
+				PMIC0ManufacturerID:               0x8a01,
+				PMIC0RevisionNumber:               0x0012,
+				RCDManufacturerID:                 0x3206,
+				RCDRevisionNumber:                 0x0034,
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d memory.Device
+			err := d.Get(tt.s)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.d, &d); diff != "" {
+				t.Fatalf("unexpected memory device (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// device37 returns the formatted area of an SMBIOS 3.7 memory device.
+func device37() []byte {
+	b := make([]byte, 96)
+	le := binary.LittleEndian
+
+	le.PutUint16(b[0:2], 0x1000)
+	le.PutUint16(b[2:4], 0xfffe)
+	le.PutUint16(b[4:6], 80)
+	le.PutUint16(b[6:8], 64)
+	le.PutUint16(b[8:10], 0x7fff)
+	b[10] = 0x09
+	b[11] = 0x01
+	b[12] = 0x01
+	b[14] = 0x22
+	le.PutUint16(b[15:17], 0x2080)
+	le.PutUint16(b[17:19], 0xffff)
+	b[23] = 0x01
+	le.PutUint32(b[24:28], 64*1024)
+	le.PutUint16(b[28:30], 4800)
+	le.PutUint16(b[30:32], 1100)
+	le.PutUint16(b[32:34], 1100)
+	le.PutUint16(b[34:36], 1100)
+	b[36] = 0x03
+	le.PutUint16(b[37:39], 0x0008)
+	b[39] = 0x02
+	le.PutUint16(b[40:42], 0xce80)
+	le.PutUint16(b[42:44], 0x0001)
+	le.PutUint16(b[44:46], 0x3206)
+	le.PutUint16(b[46:48], 0x0002)
+	le.PutUint64(b[56:64], 64<<30)
+	le.PutUint64(b[64:72], ^uint64(0))
+	le.PutUint32(b[80:84], 70000)
+	le.PutUint32(b[84:88], 0)
+	le.PutUint16(b[88:90], 0x8a01)
+	le.PutUint16(b[90:92], 0x0012)
+	le.PutUint16(b[92:94], 0x3206)
+	le.PutUint16(b[94:96], 0x0034)
+
+	return b
+}
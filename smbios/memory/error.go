@@ -0,0 +1,262 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/axrayn/go-smbios/smbios"
+)
+
+const (
+	// type32BitError is the SMBIOS structure type for 32-Bit Memory Error
+	// Information.
+	type32BitError = 18
+
+	// type64BitError is the SMBIOS structure type for 64-Bit Memory Error
+	// Information.
+	type64BitError = 33
+)
+
+// Handle values used by memory arrays and devices which do not refer to
+// memory error information.
+const (
+	errorHandleNotProvided = 0xfffe
+	errorHandleNoError     = 0xffff
+)
+
+// UnknownAddress is used for error addresses and resolutions which are
+// reported as unknown.
+const UnknownAddress = ^uint64(0)
+
+var (
+	errorTypeList = map[int]string{
+		0x01: "Other",
+		0x02: "Unknown",
+		0x03: "OK",
+		0x04: "Bad read",
+		0x05: "Parity error",
+		0x06: "Single-bit error",
+		0x07: "Double-bit error",
+		0x08: "Multi-bit error",
+		0x09: "Nibble error",
+		0x0a: "Checksum error",
+		0x0b: "CRC error",
+		0x0c: "Corrected single-bit error",
+		0x0d: "Corrected error",
+		0x0e: "Uncorrectable error",
+	}
+
+	errorGranularityList = map[int]string{
+		0x01: "Other",
+		0x02: "Unknown",
+		0x03: "Device level",
+		0x04: "Memory partition level",
+	}
+
+	errorOperationList = map[int]string{
+		0x01: "Other",
+		0x02: "Unknown",
+		0x03: "Read",
+		0x04: "Write",
+		0x05: "Partial write",
+	}
+)
+
+// ErrorInfo Structure for containing 32-Bit or 64-Bit Memory Error
+// information
+//
+// Addresses and Resolution are in bytes, and use UnknownAddress when they
+// are reported as unknown.  VendorSyndrome is 0 if it is unknown.
+type ErrorInfo struct {
+	Type               string
+	Granularity        string
+	Operation          string
+	VendorSyndrome     uint32
+	ArrayErrorAddress  uint64
+	DeviceErrorAddress uint64
+	Resolution         uint64
+}
+
+// Detected reports whether the error information describes an error, as
+// opposed to an OK or unknown status.
+func (e *ErrorInfo) Detected() bool {
+	switch e.Type {
+	case "OK", "Unknown", "":
+		return false
+	default:
+		return true
+	}
+}
+
+// get32 returns a 32-bit address or resolution, which uses only the most
+// significant bit set to indicate an unknown value.
+func get32(b []byte) uint64 {
+	v := binary.LittleEndian.Uint32(b)
+	if v == 0x80000000 {
+		return UnknownAddress
+	}
+	return uint64(v)
+}
+
+// get64 returns a 64-bit address, which uses only the most significant bit
+// set to indicate an unknown value.
+func get64(b []byte) uint64 {
+	v := binary.LittleEndian.Uint64(b)
+	if v == 0x8000000000000000 {
+		return UnknownAddress
+	}
+	return v
+}
+
+// Get Function to build a *ErrorInfo struct object with all
+// the details from SMBIOS
+func (e *ErrorInfo) Get(s *smbios.Structure) error {
+	var minLen int
+	switch s.Header.Type {
+	case type32BitError:
+		minLen = 19
+	case type64BitError:
+		minLen = 27
+	default:
+		return fmt.Errorf("expected SMBIOS structure type %d or %d, but got: %d",
+			type32BitError, type64BitError, s.Header.Type)
+	}
+
+	f := s.Formatted
+	if l := len(f); l < minLen {
+		return fmt.Errorf("expected SMBIOS memory error information length of at least %d, but got: %d", minLen, l)
+	}
+
+	*e = ErrorInfo{
+		Type:           errorTypeList[int(f[0])],
+		Granularity:    errorGranularityList[int(f[1])],
+		Operation:      errorOperationList[int(f[2])],
+		VendorSyndrome: binary.LittleEndian.Uint32(f[3:7]),
+	}
+
+	if s.Header.Type == type32BitError {
+		e.ArrayErrorAddress = get32(f[7:11])
+		e.DeviceErrorAddress = get32(f[11:15])
+		e.Resolution = get32(f[15:19])
+		return nil
+	}
+
+	e.ArrayErrorAddress = get64(f[7:15])
+	e.DeviceErrorAddress = get64(f[15:23])
+	e.Resolution = get32(f[23:27])
+
+	return nil
+}
+
+// detectedError returns the memory error information referred to by handle
+// h, if it exists in t and reports an error.
+func detectedError(t *smbios.Table, h uint16) (ErrorInfo, bool, error) {
+	switch h {
+	case errorHandleNotProvided, errorHandleNoError:
+		return ErrorInfo{}, false, nil
+	}
+
+	s, ok := t.ByHandle(h)
+	if !ok {
+		return ErrorInfo{}, false, nil
+	}
+
+	var e ErrorInfo
+	if err := e.Get(s); err != nil {
+		return ErrorInfo{}, false, err
+	}
+
+	return e, e.Detected(), nil
+}
+
+// A DeviceError is a Memory Device which reports a memory error.
+type DeviceError struct {
+	Handle uint16
+	Device Device
+	Error  ErrorInfo
+}
+
+// DeviceErrors returns every Memory Device in t which refers to memory error
+// information that reports an error, in table order.
+//
+// Only errors reported by the devices themselves are returned; errors
+// reported for a whole Physical Memory Array are returned by ArrayErrors.
+// Devices which do not provide error information, or whose error
+// information handle does not refer to a structure in t, are skipped.
+func DeviceErrors(t *smbios.Table) ([]DeviceError, error) {
+	var errs []DeviceError
+	for _, s := range t.ByType(typeMemoryDevice) {
+		var d Device
+		if err := d.Get(s); err != nil {
+			return nil, err
+		}
+
+		e, ok, err := detectedError(t, d.MemoryErrorInformationHandle)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		errs = append(errs, DeviceError{
+			Handle: s.Header.Handle,
+			Device: d,
+			Error:  e,
+		})
+	}
+
+	return errs, nil
+}
+
+// An ArrayError is a Physical Memory Array which reports a memory error,
+// along with the Memory Devices which belong to it.
+type ArrayError struct {
+	ArraySummary
+	Error ErrorInfo
+}
+
+// ArrayErrors returns every Physical Memory Array in t which refers to
+// memory error information that reports an error, in table order.  The
+// error may have occurred in any of the array's devices.
+//
+// Arrays which do not provide error information, or whose error
+// information handle does not refer to a structure in t, are skipped.
+func ArrayErrors(t *smbios.Table) ([]ArrayError, error) {
+	sums, err := Summarize(t)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []ArrayError
+	for _, sum := range sums {
+		e, ok, err := detectedError(t, sum.Array.MemoryErrorInformationHandle)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		errs = append(errs, ArrayError{
+			ArraySummary: sum,
+			Error:        e,
+		})
+	}
+
+	return errs, nil
+}
@@ -0,0 +1,279 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/memory"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestErrorInfoGet(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		e    *memory.ErrorInfo
+		ok   bool
+	}{
+		{
+			name: "wrong type",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 17}},
+		},
+		{
+			name: "32-bit too short",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 18},
+				Formatted: []byte{0x03, 0x02, 0x02},
+			},
+		},
+		{
+			name: "64-bit too short",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 33},
+				Formatted: make([]byte, 19),
+			},
+		},
+		{
+			name: "OK, 32-bit unknown",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 18},
+				Formatted: []byte{
+					0x03, 0x02, 0x02,
+					0x00, 0x00, 0x00, 0x00,
+					0x00, 0x00, 0x00, 0x80,
+					0x00, 0x00, 0x00, 0x80,
+					0x00, 0x00, 0x00, 0x80,
+				},
+			},
+			e: &memory.ErrorInfo{
+				Type:               "OK",
+				Granularity:        "Unknown",
+				Operation:          "Unknown",
+				ArrayErrorAddress:  memory.UnknownAddress,
+				DeviceErrorAddress: memory.UnknownAddress,
+				Resolution:         memory.UnknownAddress,
+			},
+			ok: true,
+		},
+		{
+			name: "OK, 64-bit",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 33},
+				Formatted: []byte{
+					0x0c, 0x03, 0x03,
+					0x78, 0x56, 0x34, 0x12,
+					0x00, 0x10, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+					0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80,
+					0x40, 0x00, 0x00, 0x00,
+				},
+			},
+			e: &memory.ErrorInfo{
+				Type:               "Corrected single-bit error",
+				Granularity:        "Device level",
+				Operation:          "Read",
+				VendorSyndrome:     0x12345678,
+				ArrayErrorAddress:  0x100001000,
+				DeviceErrorAddress: memory.UnknownAddress,
+				Resolution:         64,
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e memory.ErrorInfo
+			err := e.Get(tt.s)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.e, &e); diff != "" {
+				t.Fatalf("unexpected memory error information (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// memoryError returns 32-bit memory error information with the specified
+// handle and error type.
+func memoryError(handle uint16, typ byte) *smbios.Structure {
+	return &smbios.Structure{
+		Header: smbios.Header{Type: 18, Handle: handle},
+		Formatted: []byte{
+			typ, 0x03, 0x03,
+			0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x80,
+			0x00, 0x00, 0x00, 0x80,
+			0x00, 0x00, 0x00, 0x80,
+		},
+	}
+}
+
+func TestDeviceErrors(t *testing.T) {
+	// device returns a memory device with the specified handle and error
+	// information handle.
+	device := func(handle, errHandle uint16) *smbios.Structure {
+		return &smbios.Structure{
+			Header: smbios.Header{Type: 17, Handle: handle},
+			Formatted: []byte{
+				0x00, 0x10,
+				byte(errHandle), byte(errHandle >> 8),
+				0x48, 0x00,
+				0x40, 0x00,
+				0x00, 0x40,
+				0x09,
+				0x00,
+				0x01, 0x00,
+				0x1a,
+				0x80, 0x00,
+			},
+			Strings: []string{"DIMM"},
+		}
+	}
+
+	tbl := smbios.NewTableFromStructures([]*smbios.Structure{
+		device(0x1100, 0xfffe),
+		device(0x1101, 0xffff),
+		device(0x1102, 0x1800),
+		device(0x1103, 0x1801),
+		device(0x1104, 0x1802),
+		// Refers to error information which does not exist.
+		device(0x1105, 0x1803),
+		memoryError(0x1800, 0x03),
+		memoryError(0x1801, 0x0e),
+		memoryError(0x1802, 0x06),
+	}, nil)
+
+	errs, err := memory.DeviceErrors(tbl)
+	if err != nil {
+		t.Fatalf("failed to get device errors: %v", err)
+	}
+
+	var (
+		handles []uint16
+		types   []string
+	)
+
+	for _, e := range errs {
+		handles = append(handles, e.Handle)
+		types = append(types, e.Error.Type)
+	}
+
+	if diff := cmp.Diff([]uint16{0x1103, 0x1104}, handles); diff != "" {
+		t.Fatalf("unexpected device handles (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"Uncorrectable error", "Single-bit error"}, types); diff != "" {
+		t.Fatalf("unexpected error types (-want +got):\n%s", diff)
+	}
+}
+
+func TestArrayErrors(t *testing.T) {
+	// array returns a physical memory array with the specified handle and
+	// error information handle.
+	array := func(handle, errHandle uint16) *smbios.Structure {
+		return &smbios.Structure{
+			Header: smbios.Header{Type: 16, Handle: handle},
+			Formatted: []byte{
+				0x03, 0x03, 0x05,
+				0x00, 0x00, 0x00, 0x04,
+				byte(errHandle), byte(errHandle >> 8),
+				0x02, 0x00,
+			},
+		}
+	}
+
+	// device returns a memory device in the specified array, which does
+	// not report its own errors.
+	device := func(handle, array uint16, locator string) *smbios.Structure {
+		return &smbios.Structure{
+			Header: smbios.Header{Type: 17, Handle: handle},
+			Formatted: []byte{
+				byte(array), byte(array >> 8),
+				0xfe, 0xff,
+				0x48, 0x00,
+				0x40, 0x00,
+				0x00, 0x40,
+				0x09,
+				0x00,
+				0x01, 0x00,
+				0x1a,
+				0x80, 0x00,
+			},
+			Strings: []string{locator},
+		}
+	}
+
+	tbl := smbios.NewTableFromStructures([]*smbios.Structure{
+		array(0x1000, 0xffff),
+		array(0x1001, 0x1800),
+		array(0x1002, 0x1801),
+		device(0x1100, 0x1000, "DIMM_A1"),
+		device(0x1101, 0x1001, "DIMM_B1"),
+		device(0x1102, 0x1001, "DIMM_B2"),
+		device(0x1103, 0x1002, "DIMM_C1"),
+		memoryError(0x1800, 0x08),
+		memoryError(0x1801, 0x03),
+	}, nil)
+
+	errs, err := memory.ArrayErrors(tbl)
+	if err != nil {
+		t.Fatalf("failed to get array errors: %v", err)
+	}
+
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 array error, but got: %d", len(errs))
+	}
+
+	e := errs[0]
+	if diff := cmp.Diff(uint16(0x1001), e.Handle); diff != "" {
+		t.Fatalf("unexpected array handle (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff("Multi-bit error", e.Error.Type); diff != "" {
+		t.Fatalf("unexpected error type (-want +got):\n%s", diff)
+	}
+
+	var locators []string
+	for _, d := range e.Devices {
+		locators = append(locators, d.DeviceLocator)
+	}
+
+	if diff := cmp.Diff([]string{"DIMM_B1", "DIMM_B2"}, locators); diff != "" {
+		t.Fatalf("unexpected array devices (-want +got):\n%s", diff)
+	}
+
+	// Array errors are not attributed to individual devices.
+	derrs, err := memory.DeviceErrors(tbl)
+	if err != nil {
+		t.Fatalf("failed to get device errors: %v", err)
+	}
+
+	if diff := cmp.Diff(0, len(derrs)); diff != "" {
+		t.Fatalf("unexpected number of device errors (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/axrayn/go-smbios/smbios"
+)
+
+const (
+	// typeArrayMappedAddress is the SMBIOS structure type for Memory Array
+	// Mapped Address.
+	typeArrayMappedAddress = 19
+
+	// typeDeviceMappedAddress is the SMBIOS structure type for Memory Device
+	// Mapped Address.
+	typeDeviceMappedAddress = 20
+)
+
+// getAddressRange returns the inclusive range of byte addresses from 32-bit
+// kilobyte starting and ending address fields, or from the extended 64-bit
+// byte address fields at ext if the 32-bit fields do not hold the range.
+func getAddressRange(f []byte, ext int) (start, end uint64, err error) {
+	start32 := binary.LittleEndian.Uint32(f[0:4])
+	end32 := binary.LittleEndian.Uint32(f[4:8])
+
+	if start32 != 0xffffffff {
+		return uint64(start32) * kib, uint64(end32)*kib + kib - 1, nil
+	}
+
+	// The extended addresses were added in SMBIOS 2.7.
+	if len(f) < ext+16 {
+		return 0, 0, fmt.Errorf("expected SMBIOS extended address length of at least %d, but got: %d", ext+16, len(f))
+	}
+
+	start = binary.LittleEndian.Uint64(f[ext : ext+8])
+	end = binary.LittleEndian.Uint64(f[ext+8 : ext+16])
+
+	return start, end, nil
+}
+
+// getPosition returns a position or depth, or -1 if it is unknown.
+func getPosition(val uint8) int {
+	if val == 0xff {
+		return -1
+	}
+	return int(val)
+}
+
+// ArrayMappedAddress Structure for containing Memory Array Mapped Address
+// information
+//
+// StartingAddress and EndingAddress are the inclusive range of physical
+// byte addresses mapped to the array.
+type ArrayMappedAddress struct {
+	StartingAddress uint64
+	EndingAddress   uint64
+	ArrayHandle     uint16
+	PartitionWidth  int
+}
+
+// Contains reports whether addr is within the mapped range.
+func (a *ArrayMappedAddress) Contains(addr uint64) bool {
+	return addr >= a.StartingAddress && addr <= a.EndingAddress
+}
+
+// Get Function to build a *ArrayMappedAddress struct object with all
+// the details from SMBIOS
+func (a *ArrayMappedAddress) Get(s *smbios.Structure) error {
+	if s.Header.Type != typeArrayMappedAddress {
+		return fmt.Errorf("expected SMBIOS structure type %d, but got: %d", typeArrayMappedAddress, s.Header.Type)
+	}
+
+	// Correct minimum length as of SMBIOS 2.1.
+	const minLen = 11
+	f := s.Formatted
+	if l := len(f); l < minLen {
+		return fmt.Errorf("expected SMBIOS memory array mapped address length of at least %d, but got: %d", minLen, l)
+	}
+
+	start, end, err := getAddressRange(f, 11)
+	if err != nil {
+		return err
+	}
+
+	*a = ArrayMappedAddress{
+		StartingAddress: start,
+		EndingAddress:   end,
+		ArrayHandle:     binary.LittleEndian.Uint16(f[8:10]),
+		PartitionWidth:  int(f[10]),
+	}
+
+	return nil
+}
+
+// DeviceMappedAddress Structure for containing Memory Device Mapped Address
+// information
+//
+// StartingAddress and EndingAddress are the inclusive range of physical
+// byte addresses mapped to the device.  PartitionRowPosition,
+// InterleavePosition, and InterleavedDataDepth are -1 if they are unknown,
+// and InterleavePosition is 0 if the device is not interleaved.
+type DeviceMappedAddress struct {
+	StartingAddress          uint64
+	EndingAddress            uint64
+	DeviceHandle             uint16
+	ArrayMappedAddressHandle uint16
+	PartitionRowPosition     int
+	InterleavePosition       int
+	InterleavedDataDepth     int
+}
+
+// Contains reports whether addr is within the mapped range.
+func (d *DeviceMappedAddress) Contains(addr uint64) bool {
+	return addr >= d.StartingAddress && addr <= d.EndingAddress
+}
+
+// Get Function to build a *DeviceMappedAddress struct object with all
+// the details from SMBIOS
+func (d *DeviceMappedAddress) Get(s *smbios.Structure) error {
+	if s.Header.Type != typeDeviceMappedAddress {
+		return fmt.Errorf("expected SMBIOS structure type %d, but got: %d", typeDeviceMappedAddress, s.Header.Type)
+	}
+
+	// Correct minimum length as of SMBIOS 2.1.
+	const minLen = 15
+	f := s.Formatted
+	if l := len(f); l < minLen {
+		return fmt.Errorf("expected SMBIOS memory device mapped address length of at least %d, but got: %d", minLen, l)
+	}
+
+	start, end, err := getAddressRange(f, 15)
+	if err != nil {
+		return err
+	}
+
+	*d = DeviceMappedAddress{
+		StartingAddress:          start,
+		EndingAddress:            end,
+		DeviceHandle:             binary.LittleEndian.Uint16(f[8:10]),
+		ArrayMappedAddressHandle: binary.LittleEndian.Uint16(f[10:12]),
+		PartitionRowPosition:     getPosition(f[12]),
+		InterleavePosition:       getPosition(f[13]),
+		InterleavedDataDepth:     getPosition(f[14]),
+	}
+
+	return nil
+}
+
+// A MappedDevice is a Memory Device along with the mapped address range
+// which refers to it.
+type MappedDevice struct {
+	Handle  uint16
+	Device  Device
+	Mapping DeviceMappedAddress
+}
+
+// DevicesAt returns the Memory Devices in t whose mapped address range
+// contains the physical address addr, in table order.  More than one device
+// is returned when the memory at addr is interleaved across devices.
+//
+// Devices are found using Memory Device Mapped Address structures, so no
+// devices are returned if the firmware does not provide them.
+func DevicesAt(t *smbios.Table, addr uint64) ([]MappedDevice, error) {
+	var mds []MappedDevice
+	for _, s := range t.ByType(typeDeviceMappedAddress) {
+		var m DeviceMappedAddress
+		if err := m.Get(s); err != nil {
+			return nil, err
+		}
+
+		if !m.Contains(addr) {
+			continue
+		}
+
+		ds, ok := t.ByHandle(m.DeviceHandle)
+		if !ok {
+			return nil, fmt.Errorf("memory device mapped address 0x%04x refers to missing memory device 0x%04x",
+				s.Header.Handle, m.DeviceHandle)
+		}
+
+		var d Device
+		if err := d.Get(ds); err != nil {
+			return nil, err
+		}
+
+		mds = append(mds, MappedDevice{
+			Handle:  m.DeviceHandle,
+			Device:  d,
+			Mapping: m,
+		})
+	}
+
+	return mds, nil
+}
@@ -0,0 +1,316 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/memory"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestArrayMappedAddressGet(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		a    *memory.ArrayMappedAddress
+		ok   bool
+	}{
+		{
+			name: "wrong type",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 20}},
+		},
+		{
+			name: "too short",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 19},
+				Formatted: []byte{0x00, 0x00, 0x00, 0x00},
+			},
+		},
+		{
+			name: "extended address missing",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 19},
+				Formatted: []byte{
+					0xff, 0xff, 0xff, 0xff,
+					0xff, 0xff, 0xff, 0xff,
+					0x00, 0x10,
+					0x02,
+				},
+			},
+		},
+		{
+			name: "OK, 2.1",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 19},
+				Formatted: []byte{
+					0x00, 0x00, 0x00, 0x00,
+					0xff, 0xff, 0x3f, 0x00,
+					0x00, 0x10,
+					0x02,
+				},
+			},
+			a: &memory.ArrayMappedAddress{
+				EndingAddress:  4<<30 - 1,
+				ArrayHandle:    0x1000,
+				PartitionWidth: 2,
+			},
+			ok: true,
+		},
+		{
+			name: "OK, 2.7, extended",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 19},
+				Formatted: []byte{
+					0xff, 0xff, 0xff, 0xff,
+					0xff, 0xff, 0xff, 0xff,
+					0x00, 0x10,
+					0x02,
+					0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+					0xff, 0xff, 0xff, 0xff, 0x08, 0x00, 0x00, 0x00,
+				},
+			},
+			a: &memory.ArrayMappedAddress{
+				StartingAddress: 4 << 30,
+				EndingAddress:   36<<30 - 1,
+				ArrayHandle:     0x1000,
+				PartitionWidth:  2,
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var a memory.ArrayMappedAddress
+			err := a.Get(tt.s)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.a, &a); diff != "" {
+				t.Fatalf("unexpected memory array mapped address (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// deviceMapping returns a memory device mapped address structure for the
+// inclusive byte address range, using the extended address fields if the
+// range does not fit in the 32-bit kilobyte fields.
+func deviceMapping(handle, device uint16, start, end uint64, interleave byte) *smbios.Structure {
+	f := make([]byte, 31)
+	le := binary.LittleEndian
+
+	if end < 4<<40 {
+		le.PutUint32(f[0:4], uint32(start>>10))
+		le.PutUint32(f[4:8], uint32(end>>10))
+	} else {
+		le.PutUint32(f[0:4], 0xffffffff)
+		le.PutUint32(f[4:8], 0xffffffff)
+		le.PutUint64(f[15:23], start)
+		le.PutUint64(f[23:31], end)
+	}
+
+	le.PutUint16(f[8:10], device)
+	le.PutUint16(f[10:12], 0x1300)
+	f[12] = 0xff
+	f[13] = interleave
+	f[14] = 0x02
+
+	return &smbios.Structure{
+		Header:    smbios.Header{Type: 20, Handle: handle},
+		Formatted: f,
+	}
+}
+
+func TestDeviceMappedAddressGet(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		d    *memory.DeviceMappedAddress
+		ok   bool
+	}{
+		{
+			name: "wrong type",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 19}},
+		},
+		{
+			name: "too short",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 20},
+				Formatted: make([]byte, 11),
+			},
+		},
+		{
+			name: "OK",
+			s:    deviceMapping(0x1400, 0x1100, 0, 8<<30-1, 1),
+			d: &memory.DeviceMappedAddress{
+				EndingAddress:            8<<30 - 1,
+				DeviceHandle:             0x1100,
+				ArrayMappedAddressHandle: 0x1300,
+				PartitionRowPosition:     -1,
+				InterleavePosition:       1,
+				InterleavedDataDepth:     2,
+			},
+			ok: true,
+		},
+		{
+			name: "OK, extended",
+			s:    deviceMapping(0x1400, 0x1100, 4<<40, 8<<40-1, 0xff),
+			d: &memory.DeviceMappedAddress{
+				StartingAddress:          4 << 40,
+				EndingAddress:            8<<40 - 1,
+				DeviceHandle:             0x1100,
+				ArrayMappedAddressHandle: 0x1300,
+				PartitionRowPosition:     -1,
+				InterleavePosition:       -1,
+				InterleavedDataDepth:     2,
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d memory.DeviceMappedAddress
+			err := d.Get(tt.s)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.d, &d); diff != "" {
+				t.Fatalf("unexpected memory device mapped address (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDevicesAt(t *testing.T) {
+	// device returns a memory device with the specified handle and
+	// locator.
+	device := func(handle uint16, locator string) *smbios.Structure {
+		return &smbios.Structure{
+			Header: smbios.Header{Type: 17, Handle: handle},
+			Formatted: []byte{
+				0x00, 0x10,
+				0xfe, 0xff,
+				0x48, 0x00,
+				0x40, 0x00,
+				0x00, 0x20,
+				0x09,
+				0x00,
+				0x01, 0x00,
+				0x1a,
+				0x80, 0x00,
+			},
+			Strings: []string{locator},
+		}
+	}
+
+	ss := []*smbios.Structure{
+		device(0x1100, "DIMM_A1"),
+		device(0x1101, "DIMM_B1"),
+		device(0x1102, "DIMM_C1"),
+		// DIMM_A1 and DIMM_B1 are interleaved over the first 16 GiB, and
+		// DIMM_C1 is mapped above 4 TiB.
+		deviceMapping(0x1400, 0x1100, 0, 16<<30-1, 1),
+		deviceMapping(0x1401, 0x1101, 0, 16<<30-1, 2),
+		deviceMapping(0x1402, 0x1102, 4<<40, 4<<40+8<<30-1, 0),
+	}
+
+	tests := []struct {
+		name     string
+		ss       []*smbios.Structure
+		addr     uint64
+		locators []string
+		ok       bool
+	}{
+		{
+			name: "missing device",
+			ss: []*smbios.Structure{
+				deviceMapping(0x1400, 0x1100, 0, 16<<30-1, 1),
+			},
+			addr: 0x1000,
+		},
+		{
+			name: "OK, unmapped",
+			ss:   ss,
+			addr: 16 << 30,
+			ok:   true,
+		},
+		{
+			name:     "OK, interleaved",
+			ss:       ss,
+			addr:     0x12345678,
+			locators: []string{"DIMM_A1", "DIMM_B1"},
+			ok:       true,
+		},
+		{
+			name:     "OK, extended",
+			ss:       ss,
+			addr:     4<<40 + 8<<30 - 1,
+			locators: []string{"DIMM_C1"},
+			ok:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mds, err := memory.DevicesAt(smbios.NewTableFromStructures(tt.ss, nil), tt.addr)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			var locators []string
+			for _, md := range mds {
+				locators = append(locators, md.Device.DeviceLocator)
+			}
+
+			if diff := cmp.Diff(tt.locators, locators); diff != "" {
+				t.Fatalf("unexpected devices (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
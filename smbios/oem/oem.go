@@ -0,0 +1,184 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package oem decodes SMBIOS OEM Strings (Type 11) and System Configuration
+// Options (Type 12) structures, and parses the metadata commonly passed
+// through them.
+package oem
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/axrayn/go-smbios/smbios"
+)
+
+const (
+	// typeOEMStrings is the SMBIOS structure type for OEM Strings.
+	typeOEMStrings = 11
+
+	// typeConfigurationOptions is the SMBIOS structure type for System
+	// Configuration Options.
+	typeConfigurationOptions = 12
+)
+
+// Prefixes used by systemd for credentials passed in OEM strings.
+const (
+	credentialPrefix       = "io.systemd.credential:"
+	binaryCredentialPrefix = "io.systemd.credential.binary:"
+)
+
+// Strings Structure for containing the strings of an OEM Strings or System
+// Configuration Options structure, in order
+type Strings []string
+
+// Get Function to build a *Strings object with all
+// the details from SMBIOS
+func (ss *Strings) Get(s *smbios.Structure) error {
+	if t := s.Header.Type; t != typeOEMStrings && t != typeConfigurationOptions {
+		return fmt.Errorf("expected SMBIOS structure type %d or %d, but got: %d",
+			typeOEMStrings, typeConfigurationOptions, t)
+	}
+
+	const minLen = 1
+	f := s.Formatted
+	if l := len(f); l < minLen {
+		return fmt.Errorf("expected SMBIOS strings length of at least %d, but got: %d", minLen, l)
+	}
+
+	n := int(f[0])
+	if l := len(s.Strings); l < n {
+		return fmt.Errorf("expected SMBIOS structure with %d strings, but got: %d", n, l)
+	}
+
+	// Strings are opaque payloads, so they are copied as-is rather than
+	// trimmed like other SMBIOS strings.
+	*ss = make(Strings, n)
+	copy(*ss, s.Strings[:n])
+
+	return nil
+}
+
+// OEMStrings returns the strings of all OEM Strings structures in t, in
+// table order.
+func OEMStrings(t *smbios.Table) (Strings, error) {
+	return fromTable(t, typeOEMStrings)
+}
+
+// ConfigurationOptions returns the strings of all System Configuration
+// Options structures in t, in table order.
+func ConfigurationOptions(t *smbios.Table) (Strings, error) {
+	return fromTable(t, typeConfigurationOptions)
+}
+
+func fromTable(t *smbios.Table, typ uint8) (Strings, error) {
+	var out Strings
+	for _, s := range t.ByType(typ) {
+		var ss Strings
+		if err := ss.Get(s); err != nil {
+			return nil, err
+		}
+
+		out = append(out, ss...)
+	}
+
+	return out, nil
+}
+
+// Lookup returns the value of the first key=value string with the specified
+// key.  If no such string exists, it returns false.
+func (ss Strings) Lookup(key string) (string, bool) {
+	for _, s := range ss {
+		k, v, ok := ParseKeyValue(s)
+		if ok && k == key {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// Credentials returns all of the systemd credentials in ss, in order.
+func (ss Strings) Credentials() ([]Credential, error) {
+	var creds []Credential
+	for _, s := range ss {
+		c, ok, err := ParseCredential(s)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		creds = append(creds, c)
+	}
+
+	return creds, nil
+}
+
+// ParseKeyValue splits a key=value string at the first equals sign.  If s
+// does not contain an equals sign or the key is empty, it returns false.
+func ParseKeyValue(s string) (key, value string, ok bool) {
+	i := strings.IndexByte(s, '=')
+	if i < 1 {
+		return "", "", false
+	}
+
+	return s[:i], s[i+1:], true
+}
+
+// A Credential is a systemd credential passed in an OEM string, in the form
+// "io.systemd.credential:NAME=VALUE" or
+// "io.systemd.credential.binary:NAME=BASE64".
+type Credential struct {
+	Name  string
+	Value []byte
+}
+
+// ParseCredential parses a systemd credential from s.  If s does not use a
+// systemd credential prefix, it returns false.  An error is returned if s
+// uses a credential prefix but is malformed.
+func ParseCredential(s string) (Credential, bool, error) {
+	var (
+		rest   string
+		binary bool
+	)
+
+	switch {
+	case strings.HasPrefix(s, credentialPrefix):
+		rest = strings.TrimPrefix(s, credentialPrefix)
+	case strings.HasPrefix(s, binaryCredentialPrefix):
+		rest = strings.TrimPrefix(s, binaryCredentialPrefix)
+		binary = true
+	default:
+		return Credential{}, false, nil
+	}
+
+	name, value, ok := ParseKeyValue(rest)
+	if !ok {
+		return Credential{}, false, fmt.Errorf("malformed systemd credential: %q", s)
+	}
+
+	if !binary {
+		return Credential{Name: name, Value: []byte(value)}, true, nil
+	}
+
+	b, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return Credential{}, false, fmt.Errorf("malformed systemd binary credential %q: %v", name, err)
+	}
+
+	return Credential{Name: name, Value: b}, true, nil
+}
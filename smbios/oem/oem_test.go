@@ -0,0 +1,240 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oem_test
+
+import (
+	"testing"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/oem"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStringsGet(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		ss   oem.Strings
+		ok   bool
+	}{
+		{
+			name: "wrong type",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 13}},
+		},
+		{
+			name: "too short",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 11}},
+		},
+		{
+			name: "missing strings",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 11},
+				Formatted: []byte{0x03},
+				Strings:   []string{"foo", "bar"},
+			},
+		},
+		{
+			name: "OK, none",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 11},
+				Formatted: []byte{0x00},
+			},
+			ss: oem.Strings{},
+			ok: true,
+		},
+		{
+			name: "OK, OEM strings",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 11},
+				Formatted: []byte{0x02},
+				Strings:   []string{"Dell System", "5[0000]", " padded\n"},
+			},
+			ss: oem.Strings{"Dell System", "5[0000]"},
+			ok: true,
+		},
+		{
+			name: "OK, not trimmed",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 11},
+				Formatted: []byte{0x02},
+				Strings:   []string{" padded ", "io.systemd.credential:motd=hello\n"},
+			},
+			ss: oem.Strings{" padded ", "io.systemd.credential:motd=hello\n"},
+			ok: true,
+		},
+		{
+			name: "OK, configuration options",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 12},
+				Formatted: []byte{0x01},
+				Strings:   []string{"NVRAM_CLR: Clear user settable NVRAM areas"},
+			},
+			ss: oem.Strings{"NVRAM_CLR: Clear user settable NVRAM areas"},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ss oem.Strings
+			err := ss.Get(tt.s)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.ss, ss); diff != "" {
+				t.Fatalf("unexpected strings (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOEMStrings(t *testing.T) {
+	tbl := smbios.NewTableFromStructures([]*smbios.Structure{
+		{
+			Header:    smbios.Header{Type: 11, Handle: 0},
+			Formatted: []byte{0x02},
+			Strings:   []string{"vendor=acme", "region=nyc3"},
+		},
+		{
+			Header:    smbios.Header{Type: 12, Handle: 1},
+			Formatted: []byte{0x01},
+			Strings:   []string{"region=sfo2"},
+		},
+		{
+			Header:    smbios.Header{Type: 11, Handle: 2},
+			Formatted: []byte{0x02},
+			Strings: []string{
+				"io.systemd.credential:hostname=droplet",
+				"io.systemd.credential.binary:ssh.key=c2VjcmV0",
+			},
+		},
+	}, nil)
+
+	ss, err := oem.OEMStrings(tbl)
+	if err != nil {
+		t.Fatalf("failed to get OEM strings: %v", err)
+	}
+
+	want := oem.Strings{
+		"vendor=acme",
+		"region=nyc3",
+		"io.systemd.credential:hostname=droplet",
+		"io.systemd.credential.binary:ssh.key=c2VjcmV0",
+	}
+
+	if diff := cmp.Diff(want, ss); diff != "" {
+		t.Fatalf("unexpected OEM strings (-want +got):\n%s", diff)
+	}
+
+	region, ok := ss.Lookup("region")
+	if !ok {
+		t.Fatal("expected region key, but none was found")
+	}
+	if diff := cmp.Diff("nyc3", region); diff != "" {
+		t.Fatalf("unexpected region (-want +got):\n%s", diff)
+	}
+
+	creds, err := ss.Credentials()
+	if err != nil {
+		t.Fatalf("failed to get credentials: %v", err)
+	}
+
+	wantCreds := []oem.Credential{
+		{Name: "hostname", Value: []byte("droplet")},
+		{Name: "ssh.key", Value: []byte("secret")},
+	}
+
+	if diff := cmp.Diff(wantCreds, creds); diff != "" {
+		t.Fatalf("unexpected credentials (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseCredential(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      string
+		c      oem.Credential
+		isCred bool
+		ok     bool
+	}{
+		{
+			name: "no equals",
+			s:    "io.systemd.credential:hostname",
+		},
+		{
+			name: "empty name",
+			s:    "io.systemd.credential:=foo",
+		},
+		{
+			name: "bad base64",
+			s:    "io.systemd.credential.binary:key=!!!",
+		},
+		{
+			name: "OK, not a credential",
+			s:    "hostname=droplet",
+			ok:   true,
+		},
+		{
+			name:   "OK, text",
+			s:      "io.systemd.credential:motd=hello=world",
+			c:      oem.Credential{Name: "motd", Value: []byte("hello=world")},
+			isCred: true,
+			ok:     true,
+		},
+		{
+			name:   "OK, binary",
+			s:      "io.systemd.credential.binary:key=AAEC",
+			c:      oem.Credential{Name: "key", Value: []byte{0x00, 0x01, 0x02}},
+			isCred: true,
+			ok:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, isCred, err := oem.ParseCredential(tt.s)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.isCred, isCred); diff != "" {
+				t.Fatalf("unexpected credential detection (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.c, c); diff != "" {
+				t.Fatalf("unexpected credential (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package onboard
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/slots"
+)
+
+const (
+	// typeDevices is the SMBIOS structure type for On Board Devices
+	// Information.
+	typeDevices = 10
+
+	// typeExtendedDevice is the SMBIOS structure type for Onboard Devices
+	// Extended Information.
+	typeExtendedDevice = 41
+)
+
+var (
+	deviceTypeList = map[int]string{
+		0x01: "Other",
+		0x02: "Unknown",
+		0x03: "Video",
+		0x04: "SCSI Controller",
+		0x05: "Ethernet",
+		0x06: "Token Ring",
+		0x07: "Sound",
+		0x08: "PATA Controller",
+		0x09: "SATA Controller",
+		0x0a: "SAS Controller",
+		0x0b: "Wireless LAN",
+		0x0c: "Bluetooth",
+		0x0d: "WWAN",
+		0x0e: "eMMC",
+		0x0f: "NVMe Controller",
+		0x10: "UFS Controller",
+	}
+
+	// networkPrefixList maps network device types to the prefixes used by
+	// systemd for predictable interface names of onboard devices.
+	networkPrefixList = map[string]string{
+		"Ethernet":     "eno",
+		"Wireless LAN": "wlo",
+		"WWAN":         "wwo",
+	}
+)
+
+// Device Structure for containing an On Board Devices Information entry
+type Device struct {
+	Type        string
+	Enabled     bool
+	Description string
+}
+
+// Devices Structure for containing all of the entries of an On Board Devices
+// Information structure
+type Devices []Device
+
+// Get Function to build a *Devices object with all
+// the details from SMBIOS
+func (ds *Devices) Get(s *smbios.Structure) error {
+	if s.Header.Type != typeDevices {
+		return fmt.Errorf("expected SMBIOS structure type %d, but got: %d", typeDevices, s.Header.Type)
+	}
+
+	// Each device is a device type byte followed by a description string.
+	f := s.Formatted
+	if l := len(f); l%2 != 0 {
+		return fmt.Errorf("expected SMBIOS on board devices length to be a multiple of 2, but got: %d", l)
+	}
+
+	*ds = nil
+	for i := 0; i < len(f); i += 2 {
+		*ds = append(*ds, Device{
+			Type:        deviceTypeList[int(f[i]&0x7f)],
+			Enabled:     f[i]&0x80 != 0,
+			Description: s.GetString(f[i+1]),
+		})
+	}
+
+	return nil
+}
+
+// ExtendedDevice Structure for containing Onboard Devices Extended
+// information
+type ExtendedDevice struct {
+	ReferenceDesignation string
+	Type                 string
+	Enabled              bool
+	Instance             int
+	Device               slots.Device
+}
+
+// NetworkName returns the predictable network interface name which systemd
+// assigns to the device, such as "eno1".  It returns an empty string if the
+// device is not a network device.
+func (d *ExtendedDevice) NetworkName() string {
+	prefix, ok := networkPrefixList[d.Type]
+	if !ok {
+		return ""
+	}
+
+	return prefix + strconv.Itoa(d.Instance)
+}
+
+// Get Function to build a *ExtendedDevice struct object with all
+// the details from SMBIOS
+func (d *ExtendedDevice) Get(s *smbios.Structure) error {
+	if s.Header.Type != typeExtendedDevice {
+		return fmt.Errorf("expected SMBIOS structure type %d, but got: %d", typeExtendedDevice, s.Header.Type)
+	}
+
+	const minLen = 7
+	f := s.Formatted
+	if l := len(f); l < minLen {
+		return fmt.Errorf("expected SMBIOS onboard devices extended length of at least %d, but got: %d", minLen, l)
+	}
+
+	*d = ExtendedDevice{
+		ReferenceDesignation: s.GetString(f[0]),
+		Type:                 deviceTypeList[int(f[1]&0x7f)],
+		Enabled:              f[1]&0x80 != 0,
+		Instance:             int(f[2]),
+		Device: slots.Device{
+			SegmentGroup: binary.LittleEndian.Uint16(f[3:5]),
+			Bus:          f[5],
+			Device:       f[6] >> 3,
+			Function:     f[6] & 0x07,
+		},
+	}
+
+	return nil
+}
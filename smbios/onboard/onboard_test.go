@@ -0,0 +1,249 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package onboard_test
+
+import (
+	"testing"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/onboard"
+	"github.com/axrayn/go-smbios/smbios/slots"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDevicesGet(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		ds   onboard.Devices
+		ok   bool
+	}{
+		{
+			name: "wrong type",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 41}},
+		},
+		{
+			name: "odd length",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 10},
+				Formatted: []byte{0x83, 0x01, 0x85},
+			},
+		},
+		{
+			name: "OK",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 10},
+				Formatted: []byte{
+					0x83, 0x01,
+					0x05, 0x02,
+				},
+				Strings: []string{"Onboard VGA", "Onboard LAN"},
+			},
+			ds: onboard.Devices{
+				{
+					Type:        "Video",
+					Enabled:     true,
+					Description: "Onboard VGA",
+				},
+				{
+					Type:        "Ethernet",
+					Description: "Onboard LAN",
+				},
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ds onboard.Devices
+			err := ds.Get(tt.s)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.ds, ds); diff != "" {
+				t.Fatalf("unexpected on board devices (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExtendedDeviceGet(t *testing.T) {
+	tests := []struct {
+		name        string
+		s           *smbios.Structure
+		d           *onboard.ExtendedDevice
+		networkName string
+		ok          bool
+	}{
+		{
+			name: "wrong type",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 10}},
+		},
+		{
+			name: "too short",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 41},
+				Formatted: []byte{0x01, 0x85, 0x01},
+			},
+		},
+		{
+			name: "OK, ethernet",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 41},
+				Formatted: []byte{
+					0x01,
+					0x85,
+					0x01,
+					0x00, 0x00,
+					0x19,
+					// Device 0x1f, function 6.
+					0xfe,
+				},
+				Strings: []string{"Onboard LAN 1"},
+			},
+			d: &onboard.ExtendedDevice{
+				ReferenceDesignation: "Onboard LAN 1",
+				Type:                 "Ethernet",
+				Enabled:              true,
+				Instance:             1,
+				Device: slots.Device{
+					Bus:      0x19,
+					Device:   0x1f,
+					Function: 6,
+				},
+			},
+			networkName: "eno1",
+			ok:          true,
+		},
+		{
+			name: "OK, wireless",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 41},
+				Formatted: []byte{
+					0x01,
+					0x8b,
+					0x02,
+					0x01, 0x00,
+					0x3a,
+					// Device 0, function 1.
+					0x01,
+				},
+				Strings: []string{"WLAN"},
+			},
+			d: &onboard.ExtendedDevice{
+				ReferenceDesignation: "WLAN",
+				Type:                 "Wireless LAN",
+				Enabled:              true,
+				Instance:             2,
+				Device: slots.Device{
+					SegmentGroup: 1,
+					Bus:          0x3a,
+					Function:     1,
+				},
+			},
+			networkName: "wlo2",
+			ok:          true,
+		},
+		{
+			name: "OK, WWAN",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 41},
+				Formatted: []byte{
+					0x01,
+					0x0d,
+					0x03,
+					0x00, 0x00,
+					0x04,
+					0x00,
+				},
+				Strings: []string{"WWAN"},
+			},
+			d: &onboard.ExtendedDevice{
+				ReferenceDesignation: "WWAN",
+				Type:                 "WWAN",
+				Instance:             3,
+				Device: slots.Device{
+					Bus: 0x04,
+				},
+			},
+			networkName: "wwo3",
+			ok:          true,
+		},
+		{
+			name: "OK, not a network device",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 41},
+				Formatted: []byte{
+					0x01,
+					0x89,
+					0x01,
+					0x00, 0x00,
+					0x00,
+					0xfa,
+				},
+				Strings: []string{"SATA"},
+			},
+			d: &onboard.ExtendedDevice{
+				ReferenceDesignation: "SATA",
+				Type:                 "SATA Controller",
+				Enabled:              true,
+				Instance:             1,
+				Device: slots.Device{
+					Device:   0x1f,
+					Function: 2,
+				},
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d onboard.ExtendedDevice
+			err := d.Get(tt.s)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.d, &d); diff != "" {
+				t.Fatalf("unexpected onboard device (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.networkName, d.NetworkName()); diff != "" {
+				t.Fatalf("unexpected network name (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probes
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/axrayn/go-smbios/smbios"
+)
+
+const (
+	// typeCoolingDevice is the SMBIOS structure type for Cooling Device.
+	typeCoolingDevice = 27
+
+	// noProbeHandle indicates that a cooling device has no temperature
+	// probe.
+	noProbeHandle = 0xffff
+)
+
+var (
+	coolingDeviceTypeList = map[int]string{
+		0x01: "Other",
+		0x02: "Unknown",
+		0x03: "Fan",
+		0x04: "Centrifugal Blower",
+		0x05: "Chip Fan",
+		0x06: "Cabinet Fan",
+		0x07: "Power Supply Fan",
+		0x08: "Heat Pipe",
+		0x09: "Integrated Refrigeration",
+		0x10: "Active Cooling",
+		0x11: "Passive Cooling",
+	}
+)
+
+// CoolingDevice Structure for containing Cooling Device information
+//
+// UnitGroup is 0 if the device is not part of a redundant cooling unit, and
+// NominalSpeed is in revolutions per minute (RPM), or Unknown if it is
+// reported as unknown or is not present.
+type CoolingDevice struct {
+	TemperatureProbeHandle uint16
+	Type                   string
+	Status                 string
+	UnitGroup              int
+	OEMDefined             uint32
+	NominalSpeed           int
+	Description            string
+}
+
+// Get Function to build a *CoolingDevice struct object with all
+// the details from SMBIOS
+func (c *CoolingDevice) Get(s *smbios.Structure) error {
+	if s.Header.Type != typeCoolingDevice {
+		return fmt.Errorf("expected SMBIOS structure type %d, but got: %d", typeCoolingDevice, s.Header.Type)
+	}
+
+	// Correct minimum length as of SMBIOS 2.2.
+	const minLen = 8
+	f := s.Formatted
+	if l := len(f); l < minLen {
+		return fmt.Errorf("expected SMBIOS cooling device length of at least %d, but got: %d", minLen, l)
+	}
+
+	*c = CoolingDevice{
+		TemperatureProbeHandle: binary.LittleEndian.Uint16(f[0:2]),
+		Type:                   coolingDeviceTypeList[int(f[2]&0x1f)],
+		Status:                 statusList[int(f[2]>>5)],
+		UnitGroup:              int(f[3]),
+		OEMDefined:             binary.LittleEndian.Uint32(f[4:8]),
+		NominalSpeed:           Unknown,
+	}
+
+	// The nominal speed is only present in longer structures.
+	if len(f) >= 10 {
+		c.NominalSpeed = getUnsigned(f[8:10])
+	}
+
+	// The description was added in SMBIOS 2.7.
+	if len(f) >= 11 {
+		c.Description = s.GetString(f[10])
+	}
+
+	return nil
+}
+
+// TemperatureProbe returns the Temperature Probe structure which monitors
+// the cooling device, if it is present in t.  It returns false if the device
+// has no probe or the handle does not refer to a temperature probe structure.
+func (c *CoolingDevice) TemperatureProbe(t *smbios.Table) (*smbios.Structure, bool) {
+	if c.TemperatureProbeHandle == noProbeHandle {
+		return nil, false
+	}
+
+	s, ok := t.ByHandle(c.TemperatureProbeHandle)
+	if !ok || s.Header.Type != uint8(KindTemperature) {
+		return nil, false
+	}
+
+	return s, true
+}
@@ -0,0 +1,202 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package probes decodes SMBIOS Voltage Probe (Type 26), Cooling Device
+// (Type 27), Temperature Probe (Type 28), and Electrical Current Probe
+// (Type 29) structures.
+package probes
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/axrayn/go-smbios/smbios"
+)
+
+// Unknown is used for probe and cooling device values which are reported as
+// unknown.
+const Unknown = math.MinInt16
+
+// A Kind is the kind of measurement made by a Probe.  Each Kind corresponds
+// to an SMBIOS structure type.
+type Kind uint8
+
+// List of possible Kind values.
+const (
+	KindVoltage     Kind = 26
+	KindTemperature Kind = 28
+	KindCurrent     Kind = 29
+)
+
+// String returns the string representation of a Kind.
+func (k Kind) String() string {
+	switch k {
+	case KindVoltage:
+		return "voltage"
+	case KindTemperature:
+		return "temperature"
+	case KindCurrent:
+		return "current"
+	default:
+		return fmt.Sprintf("Kind(%d)", uint8(k))
+	}
+}
+
+// Unit returns the unit of a Probe's maximum, minimum, tolerance, and
+// nominal values.
+func (k Kind) Unit() string {
+	switch k {
+	case KindVoltage:
+		return "mV"
+	case KindTemperature:
+		return "1/10 °C"
+	case KindCurrent:
+		return "mA"
+	default:
+		return ""
+	}
+}
+
+// ResolutionUnit returns the unit of a Probe's resolution.
+func (k Kind) ResolutionUnit() string {
+	switch k {
+	case KindVoltage:
+		return "1/10 mV"
+	case KindTemperature:
+		return "1/1000 °C"
+	case KindCurrent:
+		return "1/10 mA"
+	default:
+		return ""
+	}
+}
+
+var (
+	locationList = map[int]string{
+		0x01: "Other",
+		0x02: "Unknown",
+		0x03: "Processor",
+		0x04: "Disk",
+		0x05: "Peripheral Bay",
+		0x06: "System Management Module",
+		0x07: "Motherboard",
+		0x08: "Memory Module",
+		0x09: "Processor Module",
+		0x0a: "Power Unit",
+		0x0b: "Add-in Card",
+		0x0c: "Front Panel Board",
+		0x0d: "Back Panel Board",
+		0x0e: "Power System Board",
+		0x0f: "Drive Back Plane",
+	}
+
+	statusList = map[int]string{
+		0x01: "Other",
+		0x02: "Unknown",
+		0x03: "OK",
+		0x04: "Non-critical",
+		0x05: "Critical",
+		0x06: "Non-recoverable",
+	}
+)
+
+// Probe Structure for containing Voltage, Temperature, or Electrical
+// Current Probe information
+//
+// Maximum, Minimum, Tolerance, and Nominal are in the units returned by
+// Kind.Unit, Resolution is in the units returned by Kind.ResolutionUnit,
+// and Accuracy is in 1/100 percent.  Values are Unknown if they are
+// reported as unknown or are not present.
+type Probe struct {
+	Kind        Kind
+	Description string
+	Location    string
+	Status      string
+	Maximum     int
+	Minimum     int
+	Resolution  int
+	Tolerance   int
+	Accuracy    int
+	OEMDefined  uint32
+	Nominal     int
+}
+
+// getSigned returns a signed probe value.  0x8000 is the most negative
+// 16-bit value, so it decodes to Unknown.
+func getSigned(b []byte) int {
+	return int(int16(binary.LittleEndian.Uint16(b)))
+}
+
+// getUnsigned returns an unsigned probe value, or Unknown for 0x8000.
+func getUnsigned(b []byte) int {
+	v := binary.LittleEndian.Uint16(b)
+	if v == 0x8000 {
+		return Unknown
+	}
+	return int(v)
+}
+
+// Get Function to build a *Probe struct object with all
+// the details from SMBIOS
+func (p *Probe) Get(s *smbios.Structure) error {
+	k := Kind(s.Header.Type)
+	switch k {
+	case KindVoltage, KindTemperature, KindCurrent:
+	default:
+		return fmt.Errorf("expected SMBIOS structure type %d, %d, or %d, but got: %d",
+			KindVoltage, KindTemperature, KindCurrent, s.Header.Type)
+	}
+
+	const minLen = 16
+	f := s.Formatted
+	if l := len(f); l < minLen {
+		return fmt.Errorf("expected SMBIOS %s probe length of at least %d, but got: %d", k, minLen, l)
+	}
+
+	*p = Probe{
+		Kind:        k,
+		Description: s.GetString(f[0]),
+		Location:    locationList[int(f[1]&0x1f)],
+		Status:      statusList[int(f[1]>>5)],
+		Maximum:     getSigned(f[2:4]),
+		Minimum:     getSigned(f[4:6]),
+		Resolution:  getUnsigned(f[6:8]),
+		Tolerance:   getSigned(f[8:10]),
+		Accuracy:    getUnsigned(f[10:12]),
+		OEMDefined:  binary.LittleEndian.Uint32(f[12:16]),
+		Nominal:     Unknown,
+	}
+
+	// The nominal value is only present in longer structures.
+	if len(f) >= 18 {
+		p.Nominal = getSigned(f[16:18])
+	}
+
+	return nil
+}
+
+// FormatValue formats a maximum, minimum, tolerance, or nominal value of
+// the probe with its unit, such as "1200 mV" or "45.5 °C".
+func (p *Probe) FormatValue(v int) string {
+	if v == Unknown {
+		return "Unknown"
+	}
+
+	if p.Kind == KindTemperature {
+		return fmt.Sprintf("%.1f °C", float64(v)/10)
+	}
+
+	return fmt.Sprintf("%d %s", v, p.Kind.Unit())
+}
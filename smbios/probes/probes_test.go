@@ -0,0 +1,335 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probes_test
+
+import (
+	"testing"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/probes"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProbeGet(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		p    *probes.Probe
+		// Maximum, minimum, and nominal values formatted with units.
+		values []string
+		ok     bool
+	}{
+		{
+			name: "wrong type",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 27}},
+		},
+		{
+			name: "too short",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 28},
+				Formatted: []byte{0x01, 0x63, 0x52, 0x03},
+			},
+		},
+		{
+			name: "OK, temperature",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 28},
+				Formatted: []byte{
+					0x01,
+					// Status OK, location Processor.
+					0x63,
+					0x52, 0x03,
+					0x9c, 0xff,
+					0x00, 0x80,
+					0xfb, 0xff,
+					0x00, 0x80,
+					0x00, 0x00, 0x00, 0x00,
+					0x00, 0x80,
+				},
+				Strings: []string{"CPU Temp"},
+			},
+			p: &probes.Probe{
+				Kind:        probes.KindTemperature,
+				Description: "CPU Temp",
+				Location:    "Processor",
+				Status:      "OK",
+				Maximum:     850,
+				Minimum:     -100,
+				Resolution:  probes.Unknown,
+				Tolerance:   -5,
+				Accuracy:    probes.Unknown,
+				Nominal:     probes.Unknown,
+			},
+			values: []string{"85.0 °C", "-10.0 °C", "Unknown"},
+			ok:     true,
+		},
+		{
+			name: "OK, voltage without nominal value",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 26},
+				Formatted: []byte{
+					0x01,
+					// Status Critical, location Motherboard.
+					0xa7,
+					0x38, 0x31,
+					0x88, 0x2c,
+					0x0a, 0x00,
+					0x00, 0x80,
+					0x32, 0x00,
+					0x78, 0x56, 0x34, 0x12,
+				},
+				Strings: []string{"12V"},
+			},
+			p: &probes.Probe{
+				Kind:        probes.KindVoltage,
+				Description: "12V",
+				Location:    "Motherboard",
+				Status:      "Critical",
+				Maximum:     12600,
+				Minimum:     11400,
+				Resolution:  10,
+				Tolerance:   probes.Unknown,
+				Accuracy:    50,
+				OEMDefined:  0x12345678,
+				Nominal:     probes.Unknown,
+			},
+			values: []string{"12600 mV", "11400 mV", "Unknown"},
+			ok:     true,
+		},
+		{
+			name: "OK, current",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 29},
+				Formatted: []byte{
+					0x01,
+					// Status Non-critical, location Power Unit.
+					0x8a,
+					0xe8, 0x03,
+					0x00, 0x00,
+					0x01, 0x00,
+					0x0a, 0x00,
+					0x64, 0x00,
+					0x00, 0x00, 0x00, 0x00,
+					0xf4, 0x01,
+				},
+				Strings: []string{"PSU Current"},
+			},
+			p: &probes.Probe{
+				Kind:        probes.KindCurrent,
+				Description: "PSU Current",
+				Location:    "Power Unit",
+				Status:      "Non-critical",
+				Maximum:     1000,
+				Resolution:  1,
+				Tolerance:   10,
+				Accuracy:    100,
+				Nominal:     500,
+			},
+			values: []string{"1000 mA", "0 mA", "500 mA"},
+			ok:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p probes.Probe
+			err := p.Get(tt.s)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.p, &p); diff != "" {
+				t.Fatalf("unexpected probe (-want +got):\n%s", diff)
+			}
+
+			values := []string{
+				p.FormatValue(p.Maximum),
+				p.FormatValue(p.Minimum),
+				p.FormatValue(p.Nominal),
+			}
+
+			if diff := cmp.Diff(tt.values, values); diff != "" {
+				t.Fatalf("unexpected formatted values (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCoolingDeviceGet(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		c    *probes.CoolingDevice
+		ok   bool
+	}{
+		{
+			name: "wrong type",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 28}},
+		},
+		{
+			name: "too short",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 27},
+				Formatted: []byte{0xff, 0xff, 0x63, 0x00},
+			},
+		},
+		{
+			name: "OK, 2.2 without nominal speed",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 27},
+				Formatted: []byte{
+					0x00, 0x28,
+					// Status OK, type Fan.
+					0x63,
+					0x01,
+					0x00, 0x00, 0x00, 0x00,
+				},
+			},
+			c: &probes.CoolingDevice{
+				TemperatureProbeHandle: 0x2800,
+				Type:                   "Fan",
+				Status:                 "OK",
+				UnitGroup:              1,
+				NominalSpeed:           probes.Unknown,
+			},
+			ok: true,
+		},
+		{
+			name: "OK, unknown nominal speed",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 27},
+				Formatted: []byte{
+					0xff, 0xff,
+					// Status Unknown, type Power Supply Fan.
+					0x47,
+					0x00,
+					0x00, 0x00, 0x00, 0x00,
+					0x00, 0x80,
+				},
+			},
+			c: &probes.CoolingDevice{
+				TemperatureProbeHandle: 0xffff,
+				Type:                   "Power Supply Fan",
+				Status:                 "Unknown",
+				NominalSpeed:           probes.Unknown,
+			},
+			ok: true,
+		},
+		{
+			name: "OK, 2.7",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 27},
+				Formatted: []byte{
+					0x00, 0x28,
+					// Status Non-critical, type Chip Fan.
+					0x85,
+					0x02,
+					0x78, 0x56, 0x34, 0x12,
+					0x70, 0x17,
+					0x01,
+				},
+				Strings: []string{"CPU Fan"},
+			},
+			c: &probes.CoolingDevice{
+				TemperatureProbeHandle: 0x2800,
+				Type:                   "Chip Fan",
+				Status:                 "Non-critical",
+				UnitGroup:              2,
+				OEMDefined:             0x12345678,
+				NominalSpeed:           6000,
+				Description:            "CPU Fan",
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c probes.CoolingDevice
+			err := c.Get(tt.s)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.c, &c); diff != "" {
+				t.Fatalf("unexpected cooling device (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCoolingDeviceTemperatureProbe(t *testing.T) {
+	voltage := &smbios.Structure{Header: smbios.Header{Type: 26, Handle: 0x26}}
+	temperature := &smbios.Structure{Header: smbios.Header{Type: 28, Handle: 0x28}}
+	tbl := smbios.NewTableFromStructures([]*smbios.Structure{voltage, temperature}, nil)
+
+	tests := []struct {
+		name string
+		h    uint16
+		s    *smbios.Structure
+		ok   bool
+	}{
+		{
+			name: "no handle",
+			h:    0xffff,
+		},
+		{
+			name: "dangling handle",
+			h:    0x29,
+		},
+		{
+			name: "not a temperature probe",
+			h:    0x26,
+		},
+		{
+			name: "OK",
+			h:    0x28,
+			s:    temperature,
+			ok:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &probes.CoolingDevice{TemperatureProbeHandle: tt.h}
+			s, ok := c.TemperatureProbe(tbl)
+
+			if diff := cmp.Diff(tt.ok, ok); diff != "" {
+				t.Fatalf("unexpected temperature probe result (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.s, s); diff != "" {
+				t.Fatalf("unexpected temperature probe (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
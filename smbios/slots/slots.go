@@ -0,0 +1,324 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package slots decodes SMBIOS System Slots (Type 9) structures.
+package slots
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/axrayn/go-smbios/smbios"
+)
+
+// typeSlot is the SMBIOS structure type for System Slots.
+const typeSlot = 9
+
+var (
+	slotTypeList = map[int]string{
+		0x01: "Other",
+		0x02: "Unknown",
+		0x03: "ISA",
+		0x04: "MCA",
+		0x05: "EISA",
+		0x06: "PCI",
+		0x07: "PC Card (PCMCIA)",
+		0x08: "VL-VESA",
+		0x09: "Proprietary",
+		0x0a: "Processor Card Slot",
+		0x0b: "Proprietary Memory Card Slot",
+		0x0c: "I/O Riser Card Slot",
+		0x0d: "NuBus",
+		0x0e: "PCI - 66MHz Capable",
+		0x0f: "AGP",
+		0x10: "AGP 2X",
+		0x11: "AGP 4X",
+		0x12: "PCI-X",
+		0x13: "AGP 8X",
+		0x14: "M.2 Socket 1-DP (Mechanical Key A)",
+		0x15: "M.2 Socket 1-SD (Mechanical Key E)",
+		0x16: "M.2 Socket 2 (Mechanical Key B)",
+		0x17: "M.2 Socket 3 (Mechanical Key M)",
+		0x18: "MXM Type I",
+		0x19: "MXM Type II",
+		0x1a: "MXM Type III (standard connector)",
+		0x1b: "MXM Type III (HE connector)",
+		0x1c: "MXM Type IV",
+		0x1d: "MXM 3.0 Type A",
+		0x1e: "MXM 3.0 Type B",
+		0x1f: "PCI Express Gen 2 SFF-8639 (U.2)",
+		0x20: "PCI Express Gen 3 SFF-8639 (U.2)",
+		0x21: "PCI Express Mini 52-pin with bottom-side keep-outs",
+		0x22: "PCI Express Mini 52-pin without bottom-side keep-outs",
+		0x23: "PCI Express Mini 76-pin",
+		0x24: "PCI Express Gen 4 SFF-8639 (U.2)",
+		0x25: "PCI Express Gen 5 SFF-8639 (U.2)",
+		0x26: "OCP NIC 3.0 Small Form Factor (SFF)",
+		0x27: "OCP NIC 3.0 Large Form Factor (LFF)",
+		0x28: "OCP NIC Prior to 3.0",
+		0x30: "CXL Flexbus 1.0",
+		0xa0: "PC-98/C20",
+		0xa1: "PC-98/C24",
+		0xa2: "PC-98/E",
+		0xa3: "PC-98/Local Bus",
+		0xa4: "PC-98/Card",
+		0xa5: "PCI Express",
+		0xa6: "PCI Express x1",
+		0xa7: "PCI Express x2",
+		0xa8: "PCI Express x4",
+		0xa9: "PCI Express x8",
+		0xaa: "PCI Express x16",
+		0xab: "PCI Express Gen 2",
+		0xac: "PCI Express Gen 2 x1",
+		0xad: "PCI Express Gen 2 x2",
+		0xae: "PCI Express Gen 2 x4",
+		0xaf: "PCI Express Gen 2 x8",
+		0xb0: "PCI Express Gen 2 x16",
+		0xb1: "PCI Express Gen 3",
+		0xb2: "PCI Express Gen 3 x1",
+		0xb3: "PCI Express Gen 3 x2",
+		0xb4: "PCI Express Gen 3 x4",
+		0xb5: "PCI Express Gen 3 x8",
+		0xb6: "PCI Express Gen 3 x16",
+		0xb8: "PCI Express Gen 4",
+		0xb9: "PCI Express Gen 4 x1",
+		0xba: "PCI Express Gen 4 x2",
+		0xbb: "PCI Express Gen 4 x4",
+		0xbc: "PCI Express Gen 4 x8",
+		0xbd: "PCI Express Gen 4 x16",
+		0xbe: "PCI Express Gen 5",
+		0xbf: "PCI Express Gen 5 x1",
+		0xc0: "PCI Express Gen 5 x2",
+		0xc1: "PCI Express Gen 5 x4",
+		0xc2: "PCI Express Gen 5 x8",
+		0xc3: "PCI Express Gen 5 x16",
+		0xc4: "PCI Express Gen 6 and Beyond",
+		0xc5: "EDSFF E1",
+		0xc6: "EDSFF E3",
+	}
+
+	dataBusWidthList = map[int]string{
+		1:  "Other",
+		2:  "Unknown",
+		3:  "8 bit",
+		4:  "16 bit",
+		5:  "32 bit",
+		6:  "64 bit",
+		7:  "128 bit",
+		8:  "x1",
+		9:  "x2",
+		10: "x4",
+		11: "x8",
+		12: "x12",
+		13: "x16",
+		14: "x32",
+	}
+
+	currentUsageList = map[int]string{
+		1: "Other",
+		2: "Unknown",
+		3: "Available",
+		4: "In use",
+		5: "Unavailable",
+	}
+
+	slotLengthList = map[int]string{
+		1: "Other",
+		2: "Unknown",
+		3: "Short Length",
+		4: "Long Length",
+		5: "2.5\" drive form factor",
+		6: "3.5\" drive form factor",
+	}
+
+	// characteristics1List is indexed by bit number.
+	characteristics1List = []string{
+		"Characteristics unknown",
+		"Provides 5.0 volts",
+		"Provides 3.3 volts",
+		"Opening is shared with another slot",
+		"PC Card-16 is supported",
+		"CardBus is supported",
+		"Zoom Video is supported",
+		"Modem Ring Resume is supported",
+	}
+
+	// characteristics2List is indexed by bit number.
+	characteristics2List = []string{
+		"PME signal is supported",
+		"Hot-plug devices are supported",
+		"SMBus signal is supported",
+		"PCIe slot bifurcation is supported",
+		"Async/surprise removal is supported",
+		"Flexbus slot, CXL 1.0 capable",
+		"Flexbus slot, CXL 2.0 capable",
+		"Flexbus slot, CXL 3.0 capable",
+	}
+
+	slotHeightList = map[int]string{
+		0: "Not applicable",
+		1: "Other",
+		2: "Unknown",
+		3: "Full height",
+		4: "Low-profile",
+	}
+)
+
+// A Device is a PCI segment group, bus, device, and function address.
+type Device struct {
+	SegmentGroup uint16
+	Bus          uint8
+	Device       uint8
+	Function     uint8
+}
+
+// Valid reports whether d holds a PCI address.  Slots which are not PCI
+// based set all of the address fields to ones.
+func (d Device) Valid() bool {
+	return !(d.SegmentGroup == 0xffff && d.Bus == 0xff && d.Device == 0x1f && d.Function == 0x07)
+}
+
+// String returns the address in the format used for device names in
+// /sys/bus/pci/devices, such as "0000:3b:00.0".
+func (d Device) String() string {
+	return fmt.Sprintf("%04x:%02x:%02x.%x", d.SegmentGroup, d.Bus, d.Device, d.Function)
+}
+
+// A PeerDevice is a device which shares a slot with the slot's own device,
+// such as a function of a bifurcated slot.
+type PeerDevice struct {
+	Device
+	DataBusWidth int
+}
+
+// Slot Structure for containing System Slots information
+//
+// BaseDataBusWidth and the DataBusWidth of peer devices are in lanes, and
+// Pitch is in units of 1/100 millimeter, or 0 if it is not given.
+type Slot struct {
+	Designation      string
+	Type             string
+	DataBusWidth     string
+	CurrentUsage     string
+	Length           string
+	ID               uint16
+	Characteristics  []string
+	Device           Device
+	BaseDataBusWidth int
+	PeerDevices      []PeerDevice
+	Information      int
+	PhysicalWidth    string
+	Pitch            int
+	Height           string
+}
+
+func getFlags(val uint8, list []string) (flags []string) {
+	for bit, name := range list {
+		if val&(1<<uint(bit)) != 0 {
+			flags = append(flags, name)
+		}
+	}
+	return flags
+}
+
+// getDevice decodes a segment group, bus, and device/function address.
+func getDevice(b []byte) Device {
+	return Device{
+		SegmentGroup: binary.LittleEndian.Uint16(b[0:2]),
+		Bus:          b[2],
+		Device:       b[3] >> 3,
+		Function:     b[3] & 0x07,
+	}
+}
+
+// Get Function to build a *Slot struct object with all
+// the details from SMBIOS
+func (sl *Slot) Get(s *smbios.Structure) error {
+	if s.Header.Type != typeSlot {
+		return fmt.Errorf("expected SMBIOS structure type %d, but got: %d", typeSlot, s.Header.Type)
+	}
+
+	// Correct minimum length as of SMBIOS 2.0.
+	const minLen = 8
+	f := s.Formatted
+	if l := len(f); l < minLen {
+		return fmt.Errorf("expected SMBIOS system slot length of at least %d, but got: %d", minLen, l)
+	}
+
+	*sl = Slot{
+		Designation:     s.GetString(f[0]),
+		Type:            slotTypeList[int(f[1])],
+		DataBusWidth:    dataBusWidthList[int(f[2])],
+		CurrentUsage:    currentUsageList[int(f[3])],
+		Length:          slotLengthList[int(f[4])],
+		ID:              binary.LittleEndian.Uint16(f[5:7]),
+		Characteristics: getFlags(f[7], characteristics1List),
+		// Slots which predate SMBIOS 2.6 do not report an address.
+		Device: Device{
+			SegmentGroup: 0xffff,
+			Bus:          0xff,
+			Device:       0x1f,
+			Function:     0x07,
+		},
+	}
+
+	// Characteristics 2 were added in SMBIOS 2.1.
+	if len(f) < 9 {
+		return nil
+	}
+	sl.Characteristics = append(sl.Characteristics, getFlags(f[8], characteristics2List)...)
+
+	// The device address was added in SMBIOS 2.6.
+	if len(f) < 13 {
+		return nil
+	}
+	sl.Device = getDevice(f[9:13])
+
+	// Data bus width and peer devices were added in SMBIOS 3.2.
+	if len(f) < 15 {
+		return nil
+	}
+	sl.BaseDataBusWidth = int(f[13])
+
+	n := int(f[14])
+	end := 15 + 5*n
+	if len(f) < end {
+		return fmt.Errorf("expected SMBIOS system slot length of at least %d for %d peer devices, but got: %d", end, n, len(f))
+	}
+
+	for i := 15; i < end; i += 5 {
+		sl.PeerDevices = append(sl.PeerDevices, PeerDevice{
+			Device:       getDevice(f[i : i+4]),
+			DataBusWidth: int(f[i+4]),
+		})
+	}
+
+	// Slot information, physical width, and pitch were added in SMBIOS
+	// 3.4.
+	f = f[end:]
+	if len(f) < 4 {
+		return nil
+	}
+	sl.Information = int(f[0])
+	sl.PhysicalWidth = dataBusWidthList[int(f[1])]
+	sl.Pitch = int(binary.LittleEndian.Uint16(f[2:4]))
+
+	// Slot height was added in SMBIOS 3.5.
+	if len(f) < 5 {
+		return nil
+	}
+	sl.Height = slotHeightList[int(f[4])]
+
+	return nil
+}
@@ -0,0 +1,228 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slots_test
+
+import (
+	"testing"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/slots"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSlotGet(t *testing.T) {
+	noDevice := slots.Device{
+		SegmentGroup: 0xffff,
+		Bus:          0xff,
+		Device:       0x1f,
+		Function:     0x07,
+	}
+
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		sl   *slots.Slot
+		ok   bool
+	}{
+		{
+			name: "wrong type",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 8}},
+		},
+		{
+			name: "too short",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 9},
+				Formatted: []byte{0x01, 0x06, 0x05},
+			},
+		},
+		{
+			name: "peer devices truncated",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 9},
+				Formatted: []byte{
+					0x01, 0xbd, 0x0d, 0x04, 0x04,
+					0x01, 0x00,
+					0x04,
+					0x01,
+					0x00, 0x00, 0x3b, 0x00,
+					0x10,
+					0x02,
+					0x00, 0x00, 0x3b, 0x01, 0x08,
+				},
+			},
+		},
+		{
+			name: "OK, 2.0",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 9},
+				Formatted: []byte{
+					0x01, 0x06, 0x05, 0x03, 0x03,
+					0x02, 0x00,
+					0x06,
+				},
+				Strings: []string{"PCI1"},
+			},
+			sl: &slots.Slot{
+				Designation:     "PCI1",
+				Type:            "PCI",
+				DataBusWidth:    "32 bit",
+				CurrentUsage:    "Available",
+				Length:          "Short Length",
+				ID:              2,
+				Characteristics: []string{"Provides 5.0 volts", "Provides 3.3 volts"},
+				Device:          noDevice,
+			},
+			ok: true,
+		},
+		{
+			name: "OK, 3.5",
+			s: &smbios.Structure{
+				Header: smbios.Header{Type: 9},
+				Formatted: []byte{
+					0x01, 0xbd, 0x0d, 0x04, 0x04,
+					0x01, 0x00,
+					0x04,
+					0x0b,
+					0x01, 0x00, 0x3b, 0x08,
+					0x10,
+					// Two peer devices.
+					0x02,
+					0x01, 0x00, 0x3b, 0x08, 0x08,
+					0x01, 0x00, 0x3b, 0x09, 0x08,
+					0x04,
+					0x0d,
+					0xd0, 0x07,
+					0x04,
+				},
+				Strings: []string{"SLOT1"},
+			},
+			sl: &slots.Slot{
+				Designation:  "SLOT1",
+				Type:         "PCI Express Gen 4 x16",
+				DataBusWidth: "x16",
+				CurrentUsage: "In use",
+				Length:       "Long Length",
+				ID:           1,
+				Characteristics: []string{
+					"Provides 3.3 volts",
+					"PME signal is supported",
+					"Hot-plug devices are supported",
+					"PCIe slot bifurcation is supported",
+				},
+				Device: slots.Device{
+					SegmentGroup: 1,
+					Bus:          0x3b,
+					Device:       1,
+				},
+				BaseDataBusWidth: 16,
+				PeerDevices: []slots.PeerDevice{
+					{
+						Device: slots.Device{
+							SegmentGroup: 1,
+							Bus:          0x3b,
+							Device:       1,
+						},
+						DataBusWidth: 8,
+					},
+					{
+						Device: slots.Device{
+							SegmentGroup: 1,
+							Bus:          0x3b,
+							Device:       1,
+							Function:     1,
+						},
+						DataBusWidth: 8,
+					},
+				},
+				Information:   4,
+				PhysicalWidth: "x16",
+				Pitch:         2000,
+				Height:        "Low-profile",
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sl slots.Slot
+			err := sl.Get(tt.s)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.sl, &sl); diff != "" {
+				t.Fatalf("unexpected system slot (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeviceString(t *testing.T) {
+	tests := []struct {
+		name  string
+		d     slots.Device
+		s     string
+		valid bool
+	}{
+		{
+			name: "not present",
+			d: slots.Device{
+				SegmentGroup: 0xffff,
+				Bus:          0xff,
+				Device:       0x1f,
+				Function:     0x07,
+			},
+			s: "ffff:ff:1f.7",
+		},
+		{
+			name:  "root segment",
+			d:     slots.Device{Bus: 0x3b},
+			s:     "0000:3b:00.0",
+			valid: true,
+		},
+		{
+			name: "function",
+			d: slots.Device{
+				SegmentGroup: 0x10,
+				Bus:          0x01,
+				Device:       0x1c,
+				Function:     0x03,
+			},
+			s:     "0010:01:1c.3",
+			valid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.s, tt.d.String()); diff != "" {
+				t.Fatalf("unexpected address (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.valid, tt.d.Valid()); diff != "" {
+				t.Fatalf("unexpected validity (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
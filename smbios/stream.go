@@ -0,0 +1,253 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package smbios
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// sysfsRoot is the default location where sysfs is mounted.
+const sysfsRoot = "/sys"
+
+// sysfs locations for SMBIOS information, relative to the sysfs root.
+var (
+	sysfsDMI        = []string{"firmware", "dmi", "tables", "DMI"}
+	sysfsEntryPoint = []string{"firmware", "dmi", "tables", "smbios_entry_point"}
+	sysfsEFISystab  = []string{"firmware", "efi", "systab"}
+	sysfsDMIID      = []string{"class", "dmi", "id"}
+)
+
+// A Mechanism is a method used to locate SMBIOS data.
+type Mechanism int
+
+// Possible Mechanism values.
+const (
+	// MechanismSysfs reads SMBIOS data from the sysfs DMI tables present in
+	// modern Linux kernels.
+	MechanismSysfs Mechanism = iota + 1
+
+	// MechanismDevMem searches for SMBIOS data in system memory using
+	// a memory device such as /dev/mem on UNIX-like systems.  If an EFI
+	// system table is present in sysfs, the entry point address it
+	// specifies is used instead of searching.
+	MechanismDevMem
+
+	// MechanismFirmwareTable retrieves SMBIOS data using Windows'
+	// GetSystemFirmwareTable.
+	MechanismFirmwareTable
+
+	// MechanismSysfsDMIID synthesizes a minimal set of SMBIOS structures
+	// from the world-readable files in Linux's /sys/class/dmi/id.  It is
+	// intended as a fallback for unprivileged processes, and is never used
+	// unless requested.  See DMIIDEntryPoint for details.
+	MechanismSysfsDMIID
+)
+
+// String implements fmt.Stringer.
+func (m Mechanism) String() string {
+	switch m {
+	case MechanismSysfs:
+		return "sysfs"
+	case MechanismDevMem:
+		return "memory device"
+	case MechanismFirmwareTable:
+		return "firmware table"
+	case MechanismSysfsDMIID:
+		return "sysfs DMI ID"
+	default:
+		return fmt.Sprintf("Mechanism(%d)", int(m))
+	}
+}
+
+// StreamOptions configures how StreamWithOptions locates SMBIOS data.  The
+// zero value of any field uses the operating system's default.
+type StreamOptions struct {
+	// SysfsRoot is the location where sysfs is mounted, for example when
+	// a host's sysfs is mounted in a container.  The default is "/sys".
+	SysfsRoot string
+
+	// MemoryPath is the path to the system memory device.  The default is
+	// "/dev/mem".
+	MemoryPath string
+
+	// ScanStart and ScanEnd bound the region of system memory which is
+	// searched for an entry point.  The default is the 0xF0000-0xFFFFF
+	// region specified by SMBIOS.  Each bound is defaulted separately, and
+	// ScanEnd must be greater than ScanStart.
+	ScanStart, ScanEnd int
+
+	// Mechanisms specifies the mechanisms used to locate SMBIOS data and
+	// the order in which they are tried.  The default is all mechanisms
+	// supported by the operating system, in order of preference.
+	Mechanisms []Mechanism
+}
+
+// StreamWithOptions locates and opens a stream of SMBIOS data and the SMBIOS
+// entry point as configured by opts.  If opts is nil, the defaults are used
+// and StreamWithOptions behaves exactly like Stream.  The stream must be
+// closed after decoding to free its resources.
+//
+// Each mechanism is tried in order.  If the data source for a mechanism does
+// not exist or permission to access it is denied, the next mechanism is
+// tried.  Any other error stops the search and is returned.
+func StreamWithOptions(opts *StreamOptions) (io.ReadCloser, EntryPoint, error) {
+	rc, ep, err := streamWithOptions(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// See Stream for details.
+	return &opaqueReadCloser{rc: rc}, ep, nil
+}
+
+// streamWithOptions is the implementation of StreamWithOptions.
+func streamWithOptions(opts *StreamOptions) (io.ReadCloser, EntryPoint, error) {
+	o, err := opts.withDefaults()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(o.Mechanisms) == 0 {
+		return nil, nil, fmt.Errorf("opening SMBIOS stream not implemented on %q", runtime.GOOS)
+	}
+
+	return openFirst(o.Mechanisms, func(m Mechanism) (io.ReadCloser, EntryPoint, error) {
+		return stream(m, o)
+	})
+}
+
+// openFirst calls open for each mechanism in order, returning the first
+// stream which opens successfully.  Mechanisms whose data source does not
+// exist or is not accessible are skipped.
+func openFirst(ms []Mechanism, open func(m Mechanism) (io.ReadCloser, EntryPoint, error)) (io.ReadCloser, EntryPoint, error) {
+	var err, permErr error
+	for _, m := range ms {
+		var (
+			rc io.ReadCloser
+			ep EntryPoint
+		)
+
+		rc, ep, err = open(m)
+		switch {
+		case err == nil:
+			return rc, ep, nil
+		case os.IsPermission(err):
+			// Remember the first permission error so the caller can tell
+			// that data exists but requires more privileges.
+			if permErr == nil {
+				permErr = err
+			}
+			continue
+		case os.IsNotExist(err):
+			// Fall back to the next mechanism.
+			continue
+		default:
+			return nil, nil, err
+		}
+	}
+
+	// No mechanism's data source was accessible; prefer reporting a
+	// permission error over the final not exist error.
+	if permErr != nil {
+		return nil, nil, permErr
+	}
+
+	return nil, nil, err
+}
+
+// stream opens the SMBIOS entry point and an SMBIOS structure stream using
+// the specified mechanism.
+func stream(m Mechanism, o *StreamOptions) (io.ReadCloser, EntryPoint, error) {
+	switch m {
+	case MechanismSysfs:
+		return sysfsStream(o.sysfsPath(sysfsEntryPoint...), o.sysfsPath(sysfsDMI...))
+	case MechanismDevMem:
+		return devMemStream(o)
+	case MechanismFirmwareTable:
+		return firmwareTableStream()
+	case MechanismSysfsDMIID:
+		return dmiIDStream(o.sysfsPath(sysfsDMIID...))
+	default:
+		return nil, nil, unsupportedMechanism(m)
+	}
+}
+
+// sysfsStream reads the SMBIOS entry point and structure stream from
+// two files; usually the modern sysfs locations.
+func sysfsStream(entryPoint, dmi string) (io.ReadCloser, EntryPoint, error) {
+	epf, err := os.Open(entryPoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer epf.Close()
+
+	ep, err := ParseEntryPoint(epf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sf, err := os.Open(dmi)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sf, ep, nil
+}
+
+// withDefaults returns a copy of o with defaults applied to unset fields, or
+// an error if the resulting options are invalid.
+func (o *StreamOptions) withDefaults() (*StreamOptions, error) {
+	var out StreamOptions
+	if o != nil {
+		out = *o
+	}
+
+	if out.SysfsRoot == "" {
+		out.SysfsRoot = sysfsRoot
+	}
+	if out.MemoryPath == "" {
+		out.MemoryPath = devMem
+	}
+	if out.ScanStart == 0 {
+		out.ScanStart = startAddr
+	}
+	if out.ScanEnd == 0 {
+		out.ScanEnd = endAddr
+	}
+	if out.ScanEnd <= out.ScanStart {
+		return nil, fmt.Errorf("SMBIOS scan end address %#x must be greater than scan start address %#x",
+			out.ScanEnd, out.ScanStart)
+	}
+	if len(out.Mechanisms) == 0 {
+		out.Mechanisms = defaultMechanisms
+	}
+
+	return &out, nil
+}
+
+// sysfsPath returns the path to a file relative to the sysfs root in o.
+func (o *StreamOptions) sysfsPath(elem ...string) string {
+	return filepath.Join(append([]string{o.SysfsRoot}, elem...)...)
+}
+
+// unsupportedMechanism returns an error for a Mechanism which is not supported
+// on this operating system.
+func unsupportedMechanism(m Mechanism) error {
+	return fmt.Errorf("SMBIOS mechanism %s not supported on %q", m, runtime.GOOS)
+}
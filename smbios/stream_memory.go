@@ -15,11 +15,14 @@
 package smbios
 
 import (
+	"bufio"
 	"bytes"
-	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -44,7 +47,27 @@ func memoryStream(rs io.ReadSeeker, startAddr, endAddr int) (io.ReadCloser, Entr
 		return nil, nil, err
 	}
 
-	// Found it; seek to the location of the entry point.
+	return memoryStreamAt(rs, addr)
+}
+
+// efiMemoryStream reads the SMBIOS entry point and structure stream from
+// an io.ReadSeeker (usually system memory), using the entry point address
+// found in an EFI system table file.
+//
+// efiMemoryStream is an entry point for tests.
+func efiMemoryStream(rs io.ReadSeeker, systab io.Reader) (io.ReadCloser, EntryPoint, error) {
+	addr, err := parseEFISystab(systab)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return memoryStreamAt(rs, addr)
+}
+
+// memoryStreamAt reads the SMBIOS entry point located at addr and the
+// structure stream it points to from an io.ReadSeeker.
+func memoryStreamAt(rs io.ReadSeeker, addr int) (io.ReadCloser, EntryPoint, error) {
+	// Seek to the location of the entry point.
 	if _, err := rs.Seek(int64(addr), io.SeekStart); err != nil {
 		return nil, nil, err
 	}
@@ -102,24 +125,96 @@ func findEntryPoint(rs io.ReadSeeker, start, end int) (int, error) {
 	}
 
 	if !found {
-		return 0, errors.New("no SMBIOS entry point found in memory")
+		return 0, fmt.Errorf("%w in memory", ErrNoEntryPoint)
 	}
 
 	// Return the exact memory location of the entry point.
 	return addr, nil
 }
 
+// errNoEFIEntryPoint indicates that an EFI system table file does not
+// contain the address of an SMBIOS entry point.
+var errNoEFIEntryPoint = fmt.Errorf("%w in EFI system table", ErrNoEntryPoint)
+
+// parseEFISystab parses the address of the SMBIOS entry point from an EFI
+// system table file, such as Linux's /sys/firmware/efi/systab.  The address
+// of the 64-bit entry point is preferred over the 32-bit entry point.
+func parseEFISystab(r io.Reader) (int, error) {
+	var (
+		addr  uint64
+		found bool
+	)
+
+	// Each line is of the form "KEY=0xADDRESS".
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		kv := strings.SplitN(strings.TrimSpace(s.Text()), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		switch kv[0] {
+		case "SMBIOS3":
+			// Always use the 64-bit entry point when present.
+			a, err := strconv.ParseUint(kv[1], 0, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid SMBIOS3 address in EFI system table: %v", err)
+			}
+
+			return int(a), nil
+		case "SMBIOS":
+			a, err := strconv.ParseUint(kv[1], 0, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid SMBIOS address in EFI system table: %v", err)
+			}
+
+			// Keep looking in case a 64-bit entry point follows.
+			addr, found = a, true
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return 0, err
+	}
+
+	if !found {
+		return 0, errNoEFIEntryPoint
+	}
+
+	return int(addr), nil
+}
+
 // devMemStream reads the SMBIOS entry point and structure stream from
-// the UNIX-like system /dev/mem device.
+// the UNIX-like system /dev/mem device, or the memory device and scan
+// region configured in o.
+//
+// On UEFI systems the entry point may not be present in the scan region,
+// so the entry point address is read from the EFI system table in sysfs
+// when it is available.
 //
 // This is UNIX-like system specific, but since it doesn't employ any system
 // calls or OS-dependent constants, it remains in this file for simplicity.
-func devMemStream() (io.ReadCloser, EntryPoint, error) {
-	mem, err := os.Open(devMem)
+func devMemStream(o *StreamOptions) (io.ReadCloser, EntryPoint, error) {
+	mem, err := os.Open(o.MemoryPath)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer mem.Close()
 
-	return memoryStream(mem, startAddr, endAddr)
+	systab, err := os.Open(o.sysfsPath(sysfsEFISystab...))
+	switch {
+	case err == nil:
+		defer systab.Close()
+
+		rc, ep, err := efiMemoryStream(mem, systab)
+		if err != errNoEFIEntryPoint {
+			return rc, ep, err
+		}
+
+		// No entry point in the system table; scan memory instead.
+	case !os.IsNotExist(err):
+		return nil, nil, err
+	}
+
+	return memoryStream(mem, o.ScanStart, o.ScanEnd)
 }
@@ -16,9 +16,10 @@ package smbios
 
 import (
 	"bytes"
-	"encoding/binary"
+	"encoding"
 	"fmt"
 	"math"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -157,6 +158,102 @@ func Test_memoryStream(t *testing.T) {
 	}
 }
 
+func Test_efiMemoryStream(t *testing.T) {
+	// Memory with entry points at different addresses which point to tables
+	// with different contents.
+	b := makeMemory(nil, nil, nil)
+
+	for _, ep := range []EntryPoint{
+		&EntryPoint32Bit{
+			StructureTableLength:  6,
+			StructureTableAddress: 0x1000,
+			NumberStructures:      1,
+		},
+		&EntryPoint64Bit{
+			StructureTableMaxSize: 6,
+			StructureTableAddress: 0x2000,
+		},
+	} {
+		addr, _ := ep.Table()
+		copy(b[addr-0x0100:], mustMarshalEntryPoint(ep))
+		copy(b[addr:], []byte{
+			127, 0x04, uint8(addr >> 8), 0x00,
+			0x00,
+			0x00,
+		})
+	}
+
+	tests := []struct {
+		name   string
+		systab string
+		handle uint16
+		ok     bool
+	}{
+		{
+			name:   "empty",
+			systab: "",
+		},
+		{
+			name:   "no SMBIOS",
+			systab: "ACPI20=0x7ffe000\nACPI=0x7ffe014\n",
+		},
+		{
+			name:   "bad address",
+			systab: "SMBIOS=0xzzzz\n",
+		},
+		{
+			name:   "no entry point at address",
+			systab: "SMBIOS=0x3000\n",
+		},
+		{
+			name:   "OK, 32",
+			systab: "ACPI20=0x7ffe000\nSMBIOS=0x0f00\n",
+			handle: 0x10,
+			ok:     true,
+		},
+		{
+			name:   "OK, prefer 64",
+			systab: "SMBIOS=0x0f00\nSMBIOS3=0x1f00\n",
+			handle: 0x20,
+			ok:     true,
+		},
+		{
+			name:   "OK, prefer 64 after 32",
+			systab: "SMBIOS3=0x1f00\nSMBIOS=0x0f00\n",
+			handle: 0x20,
+			ok:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc, _, err := efiMemoryStream(bytes.NewReader(b), strings.NewReader(tt.systab))
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+			defer rc.Close()
+
+			ss, err := NewDecoder(rc).Decode()
+			if err != nil {
+				t.Fatalf("failed to decode structures: %v", err)
+			}
+
+			if h := ss[0].Header.Handle; h != tt.handle {
+				t.Fatalf("unexpected structure handle: want %#04x, got %#04x", tt.handle, h)
+			}
+		})
+	}
+}
+
 // Memory addresses used to start and stop searching for entry points.
 const (
 	start = 0x0010
@@ -174,41 +271,15 @@ func makeMemory(before, in, after []byte) []byte {
 }
 
 func mustMarshalEntryPoint(ep EntryPoint) []byte {
-	switch x := ep.(type) {
-	case *EntryPoint64Bit:
-		return marshal64(x)
-	default:
-		// TODO(mdlayher): expand with 32-bit entry point.
+	m, ok := ep.(encoding.BinaryMarshaler)
+	if !ok {
 		panic(fmt.Sprintf("entry point marshaling not implemented for %T", ep))
 	}
-}
-
-func marshal64(ep *EntryPoint64Bit) []byte {
-	b := make([]byte, expLen64)
-
-	copy(b[0:5], magic64)
-	b[6] = expLen64
 
-	b[7] = ep.Major
-	b[8] = ep.Minor
-	b[9] = ep.Revision
-	b[10] = ep.EntryPointRevision
-	b[11] = ep.Reserved
-	binary.LittleEndian.PutUint32(b[12:16], ep.StructureTableMaxSize)
-	binary.LittleEndian.PutUint64(b[16:24], ep.StructureTableAddress)
-
-	var chk uint8
-	for i := range b {
-		// Explicitly skip the checksum byte for computation.
-		if i == chkIndex64 {
-			continue
-		}
-
-		chk += b[i]
+	b, err := m.MarshalBinary()
+	if err != nil {
+		panic(fmt.Sprintf("failed to marshal entry point: %v", err))
 	}
 
-	// Produce the correct checksum for the entry point.
-	b[5] = uint8(256 - int(chk))
-
 	return b
 }
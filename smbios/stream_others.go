@@ -16,13 +16,6 @@
 
 package smbios
 
-import (
-	"fmt"
-	"io"
-	"runtime"
-)
-
-// stream is not implemented for unsupported platforms.
-func stream() (io.ReadCloser, EntryPoint, error) {
-	return nil, nil, fmt.Errorf("opening SMBIOS stream not implemented on %q", runtime.GOOS)
-}
+// defaultMechanisms is empty for unsupported platforms, but any mechanism which
+// only reads files may still be requested explicitly.
+var defaultMechanisms []Mechanism
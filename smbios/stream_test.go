@@ -0,0 +1,296 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package smbios
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_streamWithOptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "smbios-test")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	stream := []byte{
+		127, 0x04, 0x01, 0x00,
+		0x00,
+		0x00,
+	}
+
+	ss := []*Structure{{
+		Header: Header{
+			Type:   127,
+			Length: 4,
+			Handle: 1,
+		},
+	}}
+
+	// A sysfs tree containing SMBIOS tables, as it would appear when a host's
+	// sysfs is mounted in a container.
+	sysfs := filepath.Join(dir, "host", "sys")
+	tables := filepath.Join(sysfs, "firmware", "dmi", "tables")
+	if err := os.MkdirAll(tables, 0755); err != nil {
+		t.Fatalf("failed to create sysfs tables directory: %v", err)
+	}
+
+	sysEP, err := NewEntryPoint64Bit(3, 0, 0, 0x1000, ss)
+	if err != nil {
+		t.Fatalf("failed to create entry point: %v", err)
+	}
+
+	mustWriteFile(t, filepath.Join(tables, "smbios_entry_point"), mustMarshalEntryPoint(sysEP))
+	mustWriteFile(t, filepath.Join(tables, "DMI"), stream)
+
+	// A memory image containing an entry point and table.
+	memEP, err := NewEntryPoint32Bit(2, 8, 0x00f0, ss)
+	if err != nil {
+		t.Fatalf("failed to create entry point: %v", err)
+	}
+
+	mem := makeMemory(nil, mustMarshalEntryPoint(memEP), nil)
+	copy(mem[0x00f0:], stream)
+
+	memPath := filepath.Join(dir, "mem")
+	mustWriteFile(t, memPath, mem)
+
+	// A sysfs tree with no SMBIOS tables, but with an EFI system table
+	// which points to the entry point in memory.
+	efi := filepath.Join(dir, "efi")
+	if err := os.MkdirAll(filepath.Join(efi, "firmware", "efi"), 0755); err != nil {
+		t.Fatalf("failed to create sysfs EFI directory: %v", err)
+	}
+
+	systab := fmt.Sprintf("ACPI20=0x7ffe000\nACPI=0x7ffe014\nSMBIOS=%#x\n", start)
+	mustWriteFile(t, filepath.Join(efi, "firmware", "efi", "systab"), []byte(systab))
+
+	tests := []struct {
+		name string
+		opts *StreamOptions
+		ep   EntryPoint
+		ok   bool
+	}{
+		{
+			name: "unknown mechanism",
+			opts: &StreamOptions{
+				Mechanisms: []Mechanism{0},
+			},
+		},
+		{
+			name: "no sources exist",
+			opts: &StreamOptions{
+				SysfsRoot:  filepath.Join(dir, "nope"),
+				MemoryPath: filepath.Join(dir, "nope"),
+				Mechanisms: []Mechanism{MechanismSysfs, MechanismDevMem},
+			},
+		},
+		{
+			name: "no entry point in scan range",
+			opts: &StreamOptions{
+				SysfsRoot:  filepath.Join(dir, "nope"),
+				MemoryPath: memPath,
+				ScanStart:  0x0100,
+				ScanEnd:    0x0200,
+				Mechanisms: []Mechanism{MechanismDevMem},
+			},
+		},
+		{
+			name: "scan end before scan start",
+			opts: &StreamOptions{
+				SysfsRoot:  filepath.Join(dir, "nope"),
+				MemoryPath: memPath,
+				ScanStart:  0x0200,
+				ScanEnd:    0x0100,
+				Mechanisms: []Mechanism{MechanismDevMem},
+			},
+		},
+		{
+			name: "OK, sysfs",
+			opts: &StreamOptions{
+				SysfsRoot:  sysfs,
+				Mechanisms: []Mechanism{MechanismSysfs, MechanismDevMem},
+			},
+			ep: sysEP,
+			ok: true,
+		},
+		{
+			name: "OK, memory",
+			opts: &StreamOptions{
+				SysfsRoot:  filepath.Join(dir, "nope"),
+				MemoryPath: memPath,
+				ScanStart:  start,
+				ScanEnd:    end,
+				Mechanisms: []Mechanism{MechanismDevMem, MechanismSysfs},
+			},
+			ep: memEP,
+			ok: true,
+		},
+		{
+			name: "OK, memory with default scan end",
+			opts: &StreamOptions{
+				SysfsRoot:  filepath.Join(dir, "nope"),
+				MemoryPath: memPath,
+				ScanStart:  start,
+				Mechanisms: []Mechanism{MechanismDevMem},
+			},
+			ep: memEP,
+			ok: true,
+		},
+		{
+			name: "OK, memory from EFI system table",
+			opts: &StreamOptions{
+				SysfsRoot:  efi,
+				MemoryPath: memPath,
+				// Entry point is outside of the scan range.
+				ScanStart:  0x0100,
+				ScanEnd:    0x0200,
+				Mechanisms: []Mechanism{MechanismSysfs, MechanismDevMem},
+			},
+			ep: memEP,
+			ok: true,
+		},
+		{
+			name: "OK, fall back to memory",
+			opts: &StreamOptions{
+				SysfsRoot:  filepath.Join(dir, "nope"),
+				MemoryPath: memPath,
+				ScanStart:  start,
+				ScanEnd:    end,
+				Mechanisms: []Mechanism{MechanismSysfs, MechanismDevMem},
+			},
+			ep: memEP,
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc, ep, err := StreamWithOptions(tt.opts)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+			defer rc.Close()
+
+			if diff := cmp.Diff(tt.ep, ep); diff != "" {
+				t.Fatalf("unexpected entry point (-want +got):\n%s", diff)
+			}
+
+			got, err := NewDecoder(rc).Decode()
+			if err != nil {
+				t.Fatalf("failed to decode structures: %v", err)
+			}
+
+			if diff := cmp.Diff(ss, got); diff != "" {
+				t.Fatalf("unexpected structures (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_openFirst(t *testing.T) {
+	var (
+		errPerm     = &os.PathError{Op: "open", Path: "/sys/firmware/dmi/tables/DMI", Err: os.ErrPermission}
+		errNotExist = &os.PathError{Op: "open", Path: "/dev/mem", Err: os.ErrNotExist}
+		errOther    = errors.New("malformed entry point")
+	)
+
+	tests := []struct {
+		name string
+		errs []error
+		err  error
+	}{
+		{
+			name: "not exist",
+			errs: []error{errNotExist, errNotExist},
+			err:  errNotExist,
+		},
+		{
+			name: "permission before not exist",
+			errs: []error{errPerm, errNotExist},
+			err:  errPerm,
+		},
+		{
+			name: "not exist before permission",
+			errs: []error{errNotExist, errPerm},
+			err:  errPerm,
+		},
+		{
+			name: "other error stops fallback",
+			errs: []error{errPerm, errOther, errNotExist},
+			err:  errOther,
+		},
+		{
+			name: "OK",
+			errs: []error{errPerm, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := make([]Mechanism, len(tt.errs))
+			for i := range ms {
+				ms[i] = Mechanism(i)
+			}
+
+			rc, _, err := openFirst(ms, func(m Mechanism) (io.ReadCloser, EntryPoint, error) {
+				if err := tt.errs[m]; err != nil {
+					return nil, nil, err
+				}
+
+				return ioutil.NopCloser(bytes.NewReader(nil)), nil, nil
+			})
+
+			if tt.err == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				rc.Close()
+				return
+			}
+
+			if diff := cmp.Diff(tt.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("unexpected error (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func mustWriteFile(t *testing.T, name string, b []byte) {
+	t.Helper()
+
+	if err := ioutil.WriteFile(name, b, 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+}
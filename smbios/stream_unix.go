@@ -19,12 +19,6 @@
 
 package smbios
 
-import (
-	"io"
-)
-
-// stream opens the SMBIOS entry point and an SMBIOS structure stream.
-func stream() (io.ReadCloser, EntryPoint, error) {
-	// Use the standard UNIX-like system method.
-	return devMemStream()
-}
+// defaultMechanisms are the mechanisms used to locate SMBIOS data, in order
+// of preference.  Use the standard UNIX-like system method.
+var defaultMechanisms = []Mechanism{MechanismDevMem}
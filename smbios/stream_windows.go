@@ -106,7 +106,13 @@ func windowsStream(buf []byte) (io.ReadCloser, EntryPoint, error) {
 	return ioutil.NopCloser(bytes.NewReader(tableBuff)), entryPoint, nil
 }
 
-func stream() (io.ReadCloser, EntryPoint, error) {
+// defaultMechanisms are the mechanisms used to locate SMBIOS data, in order
+// of preference.
+var defaultMechanisms = []Mechanism{MechanismFirmwareTable}
+
+// firmwareTableStream opens the SMBIOS entry point and an SMBIOS structure
+// stream using GetSystemFirmwareTable.
+func firmwareTableStream() (io.ReadCloser, EntryPoint, error) {
 	// Call first with empty buffer to get size.
 	r1, _, err := procGetSystemFirmwareTable.Call(
 		uintptr(firmwareTableProviderSigRSMB), // FirmwareTableProviderSignature = 'RSMB'
@@ -14,6 +14,10 @@
 
 package smbios
 
+import (
+	"strings"
+)
+
 // A Header is a Structure's header.
 type Header struct {
 	Type   uint8
@@ -27,3 +31,15 @@ type Structure struct {
 	Formatted []byte
 	Strings   []string
 }
+
+// GetString returns the string with string number n from the Structure's
+// string-set, with leading and trailing white space removed.  String numbers
+// begin at 1.  If n is 0, which indicates that no string is present, or n
+// does not refer to a string in the string-set, an empty string is returned.
+func (s *Structure) GetString(n uint8) string {
+	if n == 0 || int(n) > len(s.Strings) {
+		return ""
+	}
+
+	return strings.TrimSpace(s.Strings[n-1])
+}
@@ -0,0 +1,152 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package system decodes SMBIOS System Information (Type 1) structures.
+package system
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/axrayn/go-smbios/smbios"
+)
+
+// typeSystem is the SMBIOS structure type for System Information.
+const typeSystem = 1
+
+var (
+	wakeUpTypeList = map[int]string{
+		0: "Reserved",
+		1: "Other",
+		2: "Unknown",
+		3: "APM Timer",
+		4: "Modem Ring",
+		5: "LAN Remote",
+		6: "Power Switch",
+		7: "PCI PME#",
+		8: "AC Power Restored",
+	}
+)
+
+// A UUID is a system UUID, stored in the byte order specified by RFC 4122
+// regardless of how it is encoded in the SMBIOS structure.
+type UUID [16]byte
+
+// NotPresent reports whether the UUID is all zeros, which indicates that
+// the system does not have a UUID.
+func (u UUID) NotPresent() bool {
+	return u == UUID{}
+}
+
+// NotSettable reports whether the UUID is all 0xFF bytes, which indicates
+// that the system has a UUID but it is not currently set.
+func (u UUID) NotSettable() bool {
+	return bytes.Equal(u[:], bytes.Repeat([]byte{0xff}, len(u)))
+}
+
+// String returns the UUID in its canonical form, or "Not Present" or
+// "Not Settable" for the special values described by SMBIOS.
+func (u UUID) String() string {
+	switch {
+	case u.NotPresent():
+		return "Not Present"
+	case u.NotSettable():
+		return "Not Settable"
+	}
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16])
+}
+
+// System Structure for containing System information
+type System struct {
+	Manufacturer string
+	ProductName  string
+	Version      string
+	SerialNumber string
+	UUID         UUID
+	WakeUpType   string
+	SKUNumber    string
+	Family       string
+}
+
+// Get Function to build a *System struct object with all
+// the details from SMBIOS, assuming SMBIOS 2.6 or later.
+//
+// Use GetWithEntryPoint to decode the UUID correctly for earlier versions.
+func (sys *System) Get(s *smbios.Structure) error {
+	return sys.GetWithEntryPoint(s, nil)
+}
+
+// GetWithEntryPoint builds a *System struct object using the SMBIOS version
+// from ep to determine the byte order of the UUID.  If ep is nil, SMBIOS 2.6
+// or later is assumed.
+func (sys *System) GetWithEntryPoint(s *smbios.Structure, ep smbios.EntryPoint) error {
+	if s.Header.Type != typeSystem {
+		return fmt.Errorf("expected SMBIOS structure type %d, but got: %d", typeSystem, s.Header.Type)
+	}
+
+	// The SMBIOS 2.0 structure only contains strings.
+	const minLen = 4
+	if l := len(s.Formatted); l < minLen {
+		return fmt.Errorf("expected SMBIOS system information length of at least %d, but got: %d", minLen, l)
+	}
+
+	*sys = System{
+		Manufacturer: s.GetString(s.Formatted[0]),
+		ProductName:  s.GetString(s.Formatted[1]),
+		Version:      s.GetString(s.Formatted[2]),
+		SerialNumber: s.GetString(s.Formatted[3]),
+	}
+
+	// UUID and wake-up type were added in SMBIOS 2.1.
+	if len(s.Formatted) >= 21 {
+		sys.UUID = parseUUID(s.Formatted[4:20], littleEndianUUID(ep))
+		sys.WakeUpType = wakeUpTypeList[int(s.Formatted[20])]
+	}
+
+	// SKU number and family were added in SMBIOS 2.4.
+	if len(s.Formatted) >= 23 {
+		sys.SKUNumber = s.GetString(s.Formatted[21])
+		sys.Family = s.GetString(s.Formatted[22])
+	}
+
+	return nil
+}
+
+// littleEndianUUID reports whether the first three fields of the UUID are
+// little-endian for the SMBIOS version in ep, as required since SMBIOS 2.6.
+func littleEndianUUID(ep smbios.EntryPoint) bool {
+	if ep == nil {
+		return true
+	}
+
+	major, minor, _ := ep.Version()
+	return major > 2 || (major == 2 && minor >= 6)
+}
+
+// parseUUID parses a UUID from b, converting the first three fields from
+// little-endian if necessary.
+func parseUUID(b []byte, le bool) UUID {
+	var u UUID
+	copy(u[:], b)
+
+	// The all-0xFF and all-zero special values are unaffected by the swap.
+	if le {
+		u[0], u[1], u[2], u[3] = u[3], u[2], u[1], u[0]
+		u[4], u[5] = u[5], u[4]
+		u[6], u[7] = u[7], u[6]
+	}
+
+	return u
+}
@@ -0,0 +1,164 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system_test
+
+import (
+	"testing"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/axrayn/go-smbios/smbios/system"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSystemGet(t *testing.T) {
+	uuid := []byte{
+		0x04, 0x03, 0x02, 0x01, 0x06, 0x05, 0x08, 0x07,
+		0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+	}
+
+	full := func(uuid []byte) *smbios.Structure {
+		b := []byte{0x01, 0x02, 0x03, 0x00}
+		b = append(b, uuid...)
+		b = append(b, 0x06, 0x04, 0x05)
+
+		return &smbios.Structure{
+			Header:    smbios.Header{Type: 1, Length: uint8(4 + len(b))},
+			Formatted: b,
+			Strings:   []string{"Acme ", "Server", "1.0", "SKU-1", "Family"},
+		}
+	}
+
+	tests := []struct {
+		name string
+		s    *smbios.Structure
+		ep   smbios.EntryPoint
+		sys  *system.System
+		uuid string
+		ok   bool
+	}{
+		{
+			name: "wrong type",
+			s:    &smbios.Structure{Header: smbios.Header{Type: 2}},
+		},
+		{
+			name: "short",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 1},
+				Formatted: []byte{0x01},
+			},
+		},
+		{
+			name: "OK, 2.0",
+			s: &smbios.Structure{
+				Header:    smbios.Header{Type: 1, Length: 8},
+				Formatted: []byte{0x01, 0x02, 0x00, 0x00},
+				Strings:   []string{"Acme", "Server"},
+			},
+			sys: &system.System{
+				Manufacturer: "Acme",
+				ProductName:  "Server",
+			},
+			uuid: "Not Present",
+			ok:   true,
+		},
+		{
+			name: "OK, 2.8",
+			s:    full(uuid),
+			ep:   &smbios.EntryPoint32Bit{Major: 2, Minor: 8},
+			sys: &system.System{
+				Manufacturer: "Acme",
+				ProductName:  "Server",
+				Version:      "1.0",
+				UUID: system.UUID{
+					0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+					0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+				},
+				WakeUpType: "Power Switch",
+				SKUNumber:  "SKU-1",
+				Family:     "Family",
+			},
+			uuid: "01020304-0506-0708-090a-0b0c0d0e0f10",
+			ok:   true,
+		},
+		{
+			name: "OK, 2.4",
+			s:    full(uuid),
+			ep:   &smbios.EntryPoint32Bit{Major: 2, Minor: 4},
+			sys: &system.System{
+				Manufacturer: "Acme",
+				ProductName:  "Server",
+				Version:      "1.0",
+				UUID: system.UUID{
+					0x04, 0x03, 0x02, 0x01, 0x06, 0x05, 0x08, 0x07,
+					0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+				},
+				WakeUpType: "Power Switch",
+				SKUNumber:  "SKU-1",
+				Family:     "Family",
+			},
+			uuid: "04030201-0605-0807-090a-0b0c0d0e0f10",
+			ok:   true,
+		},
+		{
+			name: "OK, not settable",
+			s: full([]byte{
+				0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+				0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+			}),
+			ep: &smbios.EntryPoint64Bit{Major: 3},
+			sys: &system.System{
+				Manufacturer: "Acme",
+				ProductName:  "Server",
+				Version:      "1.0",
+				UUID: system.UUID{
+					0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+					0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+				},
+				WakeUpType: "Power Switch",
+				SKUNumber:  "SKU-1",
+				Family:     "Family",
+			},
+			uuid: "Not Settable",
+			ok:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sys system.System
+			err := sys.GetWithEntryPoint(tt.s, tt.ep)
+
+			if tt.ok && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("expected an error, but none occurred: %v", err)
+			}
+
+			if !tt.ok {
+				t.Logf("OK error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.sys, &sys); diff != "" {
+				t.Fatalf("unexpected system information (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.uuid, sys.UUID.String()); diff != "" {
+				t.Fatalf("unexpected UUID string (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package smbios
+
+import (
+	"encoding/binary"
+)
+
+const (
+	// handleNone and handleNoError are sentinel handle values which
+	// indicate that a handle reference does not point to a Structure.
+	handleNone    = 0xffff
+	handleNoError = 0xfffe
+)
+
+// handleFields maps a Structure type to the offsets of the handle fields it
+// contains.  Offsets are relative to the start of the Structure, as they
+// appear in the SMBIOS specification.
+var handleFields = map[uint8][]int{
+	// Baseboard Information: chassis handle.
+	2: {0x0b},
+	// Processor Information: L1, L2, and L3 cache handles.
+	4: {0x1a, 0x1c, 0x1e},
+	// Physical Memory Array: memory error information handle.
+	16: {0x0b},
+	// Memory Device: physical memory array and memory error information
+	// handles.
+	17: {0x04, 0x06},
+	// Memory Array Mapped Address: memory array handle.
+	19: {0x0c},
+	// Memory Device Mapped Address: memory device and memory array mapped
+	// address handles.
+	20: {0x0c, 0x0e},
+	// Cooling Device: temperature probe handle.
+	27: {0x04},
+}
+
+// A Table is a decoded SMBIOS structure table, indexed for lookups by
+// handle and by type.
+type Table struct {
+	ep       EntryPoint
+	ss       []*Structure
+	byHandle map[uint16]*Structure
+	byType   map[uint8][]*Structure
+}
+
+// NewTable decodes all Structures from d and indexes them in a Table along
+// with the EntryPoint which describes them.  ep may be nil if no EntryPoint
+// is available.
+func NewTable(d *Decoder, ep EntryPoint) (*Table, error) {
+	ss, err := d.Decode()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTableFromStructures(ss, ep), nil
+}
+
+// NewTableFromStructures indexes already decoded Structures in a Table.
+//
+// If more than one Structure shares a handle, the first is used for handle
+// lookups.
+func NewTableFromStructures(ss []*Structure, ep EntryPoint) *Table {
+	t := &Table{
+		ep:       ep,
+		ss:       ss,
+		byHandle: make(map[uint16]*Structure, len(ss)),
+		byType:   make(map[uint8][]*Structure),
+	}
+
+	for _, s := range ss {
+		if _, ok := t.byHandle[s.Header.Handle]; !ok {
+			t.byHandle[s.Header.Handle] = s
+		}
+
+		t.byType[s.Header.Type] = append(t.byType[s.Header.Type], s)
+	}
+
+	return t
+}
+
+// EntryPoint returns the EntryPoint used to create the Table, if any.
+func (t *Table) EntryPoint() EntryPoint {
+	return t.ep
+}
+
+// Structures returns all of the Structures in the Table in stream order.
+func (t *Table) Structures() []*Structure {
+	return t.ss
+}
+
+// ByHandle returns the Structure with the specified handle.  If no such
+// Structure exists, it returns false.
+func (t *Table) ByHandle(handle uint16) (*Structure, bool) {
+	s, ok := t.byHandle[handle]
+	return s, ok
+}
+
+// ByType returns all Structures of the specified type in stream order.
+func (t *Table) ByType(typ uint8) []*Structure {
+	return t.byType[typ]
+}
+
+// First returns the first Structure of the specified type.  If no such
+// Structure exists, it returns false.
+func (t *Table) First(typ uint8) (*Structure, bool) {
+	ss := t.byType[typ]
+	if len(ss) == 0 {
+		return nil, false
+	}
+
+	return ss[0], true
+}
+
+// A Reference is a handle stored in one Structure which refers to another
+// Structure.
+type Reference struct {
+	// From is the Structure which contains the reference.
+	From *Structure
+
+	// Offset is the offset of the handle field from the start of From,
+	// as it appears in the SMBIOS specification.
+	Offset int
+
+	// Handle is the handle being referred to.
+	Handle uint16
+}
+
+// DanglingReferences returns the handle references made by Structures in the
+// Table which do not refer to any Structure in the Table.  References which
+// use the "not provided" sentinel values 0xFFFE and 0xFFFF are ignored.
+//
+// Only handle fields at well-known offsets in the SMBIOS specification are
+// checked.
+func (t *Table) DanglingReferences() []Reference {
+	var refs []Reference
+	for _, s := range t.ss {
+		for _, r := range references(s) {
+			if _, ok := t.byHandle[r.Handle]; !ok {
+				refs = append(refs, r)
+			}
+		}
+	}
+
+	return refs
+}
+
+// references returns the handle references made by s which are expected to
+// point to another Structure.
+func references(s *Structure) []Reference {
+	var refs []Reference
+	for _, off := range handleFields[s.Header.Type] {
+		// Handle fields may not exist in Structures from older versions of
+		// the specification.
+		i := off - headerLen
+		if i+2 > len(s.Formatted) {
+			continue
+		}
+
+		h := binary.LittleEndian.Uint16(s.Formatted[i : i+2])
+		if h == handleNone || h == handleNoError {
+			continue
+		}
+
+		refs = append(refs, Reference{
+			From:   s,
+			Offset: off,
+			Handle: h,
+		})
+	}
+
+	return refs
+}
@@ -0,0 +1,111 @@
+// Copyright 2017-2018 DigitalOcean.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package smbios_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/axrayn/go-smbios/smbios"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTable(t *testing.T) {
+	b := []byte{
+		// Processor, L1 cache handle 0x0002, L2 cache handle 0x0009 (dangling),
+		// and L3 cache handle not provided.
+		0x04, 0x20, 0x01, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x02, 0x00,
+		0x09, 0x00,
+		0xff, 0xff,
+		0x00,
+		0x00,
+
+		// Cache information.
+		0x07, 0x05, 0x02, 0x00,
+		0x01,
+		0x00,
+		0x00,
+
+		// Another cache information structure.
+		0x07, 0x05, 0x03, 0x00,
+		0x02,
+		0x00,
+		0x00,
+
+		127, 0x04, 0x04, 0x00,
+		0x00,
+		0x00,
+	}
+
+	ep := &smbios.EntryPoint64Bit{Major: 3}
+	tbl, err := smbios.NewTable(smbios.NewDecoder(bytes.NewReader(b)), ep)
+	if err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+
+	if diff := cmp.Diff(smbios.EntryPoint(ep), tbl.EntryPoint()); diff != "" {
+		t.Fatalf("unexpected entry point (-want +got):\n%s", diff)
+	}
+	if l := len(tbl.Structures()); l != 4 {
+		t.Fatalf("unexpected number of structures: %d", l)
+	}
+
+	s, ok := tbl.ByHandle(0x0003)
+	if !ok {
+		t.Fatal("expected to find structure by handle")
+	}
+	if diff := cmp.Diff([]byte{0x02}, s.Formatted); diff != "" {
+		t.Fatalf("unexpected structure by handle (-want +got):\n%s", diff)
+	}
+	if _, ok := tbl.ByHandle(0x0009); ok {
+		t.Fatal("expected no structure for unknown handle")
+	}
+
+	if l := len(tbl.ByType(7)); l != 2 {
+		t.Fatalf("unexpected number of cache structures: %d", l)
+	}
+	if l := len(tbl.ByType(1)); l != 0 {
+		t.Fatalf("unexpected number of system structures: %d", l)
+	}
+
+	s, ok = tbl.First(7)
+	if !ok {
+		t.Fatal("expected to find first cache structure")
+	}
+	if h := s.Header.Handle; h != 0x0002 {
+		t.Fatalf("unexpected first cache handle: %#04x", h)
+	}
+	if _, ok := tbl.First(1); ok {
+		t.Fatal("expected no first system structure")
+	}
+
+	refs := tbl.DanglingReferences()
+	if l := len(refs); l != 1 {
+		t.Fatalf("unexpected number of dangling references: %d", l)
+	}
+
+	want := smbios.Reference{
+		From:   tbl.Structures()[0],
+		Offset: 0x1c,
+		Handle: 0x0009,
+	}
+	if diff := cmp.Diff(want, refs[0]); diff != "" {
+		t.Fatalf("unexpected dangling reference (-want +got):\n%s", diff)
+	}
+}